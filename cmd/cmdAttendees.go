@@ -0,0 +1,175 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+	"github.com/DanielRivasMD/Zenith/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// attendees live in the event_contacts join table; events.contact stays the primary contact
+var eventAttendeesCmd = &cobra.Command{
+	Use:   "attendees",
+	Short: "Manage the additional contacts attending an event",
+}
+
+var eventAttendeesListCmd = &cobra.Command{
+	Use:   "list [eventID]",
+	Short: "List the primary contact and attendees of an event",
+	Args:  cobra.ExactArgs(1),
+	Run:   runAttendeesList,
+}
+
+var eventAttendeesAddCmd = &cobra.Command{
+	Use:   "add [eventID] [contactID...]",
+	Short: "Add contacts to an event",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runAttendeesAdd,
+}
+
+var eventAttendeesRmCmd = &cobra.Command{
+	Use:   "rm [eventID] [contactID...]",
+	Short: "Remove contacts from an event",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runAttendeesRm,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	eventCmd.AddCommand(eventAttendeesCmd)
+	eventAttendeesCmd.AddCommand(eventAttendeesListCmd, eventAttendeesAddCmd, eventAttendeesRmCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runAttendeesList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	e := mustFindEvent(args[0])
+
+	primary, err := db.Found(models.FindContact(ctx, db.Conn, null.Int64From(e.Contact)))
+	if err != nil {
+		fatalFind("contact", e.Contact, err)
+	}
+	fmt.Printf("%d\t%s (primary)\n", primary.ID.Int64, primary.Name)
+
+	attendees, err := eventAttendees(ctx, e.ID.Int64)
+	if err != nil {
+		log.Fatalf("list attendees: %v", err)
+	}
+	for _, c := range attendees {
+		fmt.Printf("%d\t%s\n", c.ID.Int64, c.Name)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runAttendeesAdd(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	e := mustFindEvent(args[0])
+
+	for _, id := range mustParseContactIDs(args[1:]) {
+		if id == e.Contact {
+			fmt.Printf("contact %d is already the primary contact of event %d\n", id, e.ID.Int64)
+			continue
+		}
+		if _, err := db.Found(models.FindContact(ctx, db.Conn, null.Int64From(id))); err != nil {
+			fatalFind("contact", id, err)
+		}
+		res, err := db.Conn.ExecContext(ctx,
+			"INSERT OR IGNORE INTO event_contacts (event, contact) VALUES (?, ?)", e.ID.Int64, id)
+		if err != nil {
+			log.Fatalf("add attendee %d: %v", id, err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			fmt.Printf("contact %d already attends event %d\n", id, e.ID.Int64)
+			continue
+		}
+		fmt.Printf("Added contact %d to event %d\n", id, e.ID.Int64)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runAttendeesRm(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	e := mustFindEvent(args[0])
+
+	for _, id := range mustParseContactIDs(args[1:]) {
+		res, err := db.Conn.ExecContext(ctx,
+			"DELETE FROM event_contacts WHERE event = ? AND contact = ?", e.ID.Int64, id)
+		if err != nil {
+			log.Fatalf("remove attendee %d: %v", id, err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			fmt.Printf("contact %d does not attend event %d\n", id, e.ID.Int64)
+			continue
+		}
+		fmt.Printf("Removed contact %d from event %d\n", id, e.ID.Int64)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// eventAttendees returns the non-primary contacts of an event ordered by ID
+func eventAttendees(ctx context.Context, eventID int64) (models.ContactSlice, error) {
+	return models.Contacts(
+		qm.InnerJoin("event_contacts ec ON ec.contact = contacts.id"),
+		qm.Where("ec.event = ?", eventID),
+		qm.OrderBy("contacts.id ASC"),
+	).All(ctx, db.Conn)
+}
+
+// mustFindEvent parses an event ID argument and loads the event
+func mustFindEvent(arg string) *models.Event {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid event ID %q: %v", arg, err)
+	}
+	e, err := db.Found(models.FindEvent(context.Background(), db.Conn, null.Int64From(id)))
+	if err != nil {
+		fatalFind("event", id, err)
+	}
+	return e
+}
+
+func mustParseContactIDs(args []string) []int64 {
+	ids := make([]int64, 0, len(args))
+	for _, a := range args {
+		id, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			log.Fatalf("invalid contact ID %q: %v", a, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
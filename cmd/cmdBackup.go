@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [file]",
+	Short: "Copy the live database to a new sqlite file",
+	Long: `Write a consistent copy of the database selected by --db (or db-path) to
+file, even while another zenith is using it. The copy is a plain sqlite file
+that can be opened with --db directly. An existing file is not overwritten.
+For an archive with a manifest, use zenith dump instead.`,
+	Example: `  zenith backup out.db
+  zenith backup --db work.db ~/backups/work-$(date +%F).db`,
+	Args:              cobra.ExactArgs(1),
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runBackup,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runBackup(cmd *cobra.Command, args []string) {
+	dest := args[0]
+	if err := db.Backup(context.Background(), dest); err != nil {
+		log.Fatalf("backup: %v", err)
+	}
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		log.Fatalf("backup: %v", err)
+	}
+	fmt.Printf("backed up to %s (%s bytes)\n", dest, humanCount(int(info.Size())))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -23,6 +23,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/mail"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -39,10 +41,9 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 var contactCmd = &cobra.Command{
-	Use:     "contact",
-	Short:   "Manage contacts",
-	Long:    helpContact,
-	Example: exampleContact,
+	Use:   "contact",
+	Short: "Manage contacts",
+	Long:  helpContact,
 
 	PersistentPreRun:  persistentPreRun,
 	PersistentPostRun: persistentPostRun,
@@ -61,116 +62,101 @@ var contactEditCmd = &cobra.Command{
 	Run:   runContactEdit,
 }
 
+var contactDupesCmd = &cobra.Command{
+	Use:   "dupes",
+	Short: "List emails shared by more than one contact",
+	Long: `Group contacts by email, ignoring case and surrounding whitespace, and
+print every address used by more than one contact along with their IDs.
+Contacts without an email are skipped.`,
+	Args: cobra.NoArgs,
+	Run:  runContactDupes,
+}
+
+var contactLinkCmd = &cobra.Command{
+	Use:   "link [id]",
+	Short: "Set a contact's organization from a searchable picker",
+	Long: `Open a searchable list of organizations and link the contact to the one
+chosen, without going through the full edit form. Without an ID the contact
+is picked from a list first. Type / to filter, enter to confirm.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runContactLink,
+}
+
+var contactLikeID int64 // --like: seed the add form from an existing contact
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// contactColumns lists the contacts table columns in schema order
+var contactColumns = []string{"id", "org", "name", "role", "email", "linkedin", "phone", "created", "updated"}
+
 func init() {
 	rootCmd.AddCommand(contactCmd)
 
 	RegisterCrudSubcommands(contactCmd, "", CrudModel[*models.Contact]{
-		Singular: "contact",
-		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Contact, error) {
-			return models.Contacts(qm.OrderBy("id ASC")).All(ctx, conn)
+		Singular:     "contact",
+		Columns:      contactColumns,
+		DateColumn:   "created",
+		DefaultOrder: "name ASC",
+		ListFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) ([]*models.Contact, error) {
+			return models.Contacts(mods...).All(ctx, conn)
+		},
+		CountFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) (int64, error) {
+			return models.Contacts(mods...).Count(ctx, conn)
 		},
 		Format: func(c *models.Contact) (int64, string) {
 			return c.ID.Int64, fmt.Sprintf("%s <%s> org=%d", c.Name, c.Email.String, c.Org)
 		},
-		RemoveFn: func(ctx context.Context, conn *sql.DB, id int64) error {
-			c, err := models.FindContact(ctx, conn, null.Int64From(id))
+		RemoveFn: func(ctx context.Context, exec boil.ContextExecutor, id int64) (int64, error) {
+			return models.Contacts(qm.Where("id = ?", id)).DeleteAll(ctx, exec)
+		},
+		Dependents: func(ctx context.Context, exec boil.ContextExecutor, id int64) ([]string, error) {
+			events, err := models.Events(qm.Where("contact = ?", id)).Count(ctx, exec)
+			if err != nil {
+				return nil, err
+			}
+			attended, err := models.EventContacts(qm.Where("contact = ?", id)).Count(ctx, exec)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			_, err = c.Delete(ctx, conn)
-			return err
+			var deps []string
+			if events > 0 {
+				deps = append(deps, countLabel(int(events), "event"))
+			}
+			if attended > 0 {
+				deps = append(deps, countLabel(int(attended), "attendance"))
+			}
+			return deps, nil
+		},
+		ShowFn: func(ctx context.Context, conn *sql.DB, id int64) (string, error) {
+			c, err := db.Found(models.FindContact(ctx, conn, null.Int64From(id)))
+			if err != nil {
+				return "", err
+			}
+			return formatRecord(contactColumns, contactRecord(c)), nil
 		},
 	})
 
-	contactCmd.AddCommand(contactAddCmd, contactEditCmd)
+	contactCmd.AddCommand(contactAddCmd, contactEditCmd, contactDupesCmd, contactLinkCmd)
+	contactAddCmd.Flags().Int64Var(&contactLikeID, "like", 0, "Prefill the form from an existing contact ID")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runContactAdd(cmd *cobra.Command, args []string) {
 	c := &models.Contact{}
-
-	fields := []Field{
-		{
-			Label:   "Organization ID",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return int64(0), nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return i, nil
-			},
-			Assign: func(holder any, v any) {
-				rv := reflect.ValueOf(holder).Elem()
-				fv := rv.FieldByName("Org")
-				if !fv.IsValid() || !fv.CanSet() {
-					log.Fatalf("cannot set Org on %T", holder)
-				}
-				fv.SetInt(v.(int64))
-			},
-		},
-		{
-			Label:   "Name",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("name cannot be blank")
-				}
-				return s, nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Name").
-					SetString(v.(string))
-			},
-		},
-		{
-			Label:   "Role (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Role").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Email (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Email").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "LinkedIn (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Linkedin").
-					Set(reflect.ValueOf(v))
-			},
-		},
+	if contactLikeID != 0 {
+		c = copyRecord("contact", contactLikeID, models.FindContact)
+		// email is unique, so the copy starts without one
+		c.Email = null.String{}
 	}
 
-	RunFormWizard(fields, c)
+	if !RunFormWizard("contact-add", contactFields(c), c) {
+		return
+	}
 
-	if err := c.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		return c.Insert(context.Background(), exec, boil.Infer())
+	}); err != nil {
 		log.Fatalf("insert contact: %v", err)
 	}
 	fmt.Printf("Created contact %d\n", c.ID.Int64)
@@ -184,25 +170,143 @@ func runContactEdit(cmd *cobra.Command, args []string) {
 		log.Fatalf("invalid contact ID %q: %v", args[0], err)
 	}
 
-	c, err := models.FindContact(context.Background(), db.Conn, null.Int64From(idNum))
+	c, err := db.Found(models.FindContact(context.Background(), db.Conn, null.Int64From(idNum)))
+	if err != nil {
+		fatalFind("contact", idNum, err)
+	}
+
+	if !RunFormWizard(fmt.Sprintf("contact-edit-%d", idNum), contactFields(c), c) {
+		return
+	}
+
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		_, err := c.Update(context.Background(), exec, boil.Infer())
+		return err
+	}); err != nil {
+		log.Fatalf("update contact: %v", err)
+	}
+	fmt.Printf("Updated contact %d\n", c.ID.Int64)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runContactLink(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	var id int64
+	if len(args) == 1 {
+		n, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			log.Fatalf("invalid contact ID %q: %v", args[0], err)
+		}
+		id = n
+	} else {
+		contacts, err := models.Contacts(qm.OrderBy("name ASC")).All(ctx, db.Conn)
+		if err != nil {
+			log.Fatalf("list contacts: %v", err)
+		}
+		picks := make([]pickItem, len(contacts))
+		for i, c := range contacts {
+			picks[i] = pickItem{id: c.ID.Int64, label: fmt.Sprintf("%s <%s>", c.Name, c.Email.String)}
+		}
+		ids, ok := runPicker("Select a contact to link", picks, false)
+		if !ok || len(ids) == 0 {
+			fmt.Println("nothing changed")
+			return
+		}
+		id = ids[0]
+	}
+
+	c, err := db.Found(models.FindContact(ctx, db.Conn, null.Int64From(id)))
+	if err != nil {
+		fatalFind("contact", id, err)
+	}
+
+	orgs, err := models.Orgs(qm.OrderBy("name ASC")).All(ctx, db.Conn)
 	if err != nil {
-		log.Fatalf("find contact: %v", err)
+		log.Fatalf("list organizations: %v", err)
+	}
+	if len(orgs) == 0 {
+		log.Fatalf("no organizations to link, add one with: zenith org add")
+	}
+	picks := make([]pickItem, len(orgs))
+	names := make(map[int64]string, len(orgs))
+	for i, o := range orgs {
+		label := o.Name
+		if o.Location.String != "" {
+			label += " (" + o.Location.String + ")"
+		}
+		if o.ID.Int64 == c.Org {
+			label += "  [current]"
+		}
+		picks[i] = pickItem{id: o.ID.Int64, label: label}
+		names[o.ID.Int64] = o.Name
+	}
+
+	ids, ok := runPicker(fmt.Sprintf("Link %s to organization", c.Name), picks, false)
+	if !ok || len(ids) == 0 {
+		fmt.Println("nothing changed")
+		return
+	}
+	if ids[0] == c.Org {
+		fmt.Printf("contact %d already belongs to %s\n", id, names[c.Org])
+		return
 	}
 
-	fields := []Field{
+	c.Org = ids[0]
+	if err := db.WithTx(ctx, func(exec boil.ContextExecutor) error {
+		_, err := c.Update(ctx, exec, boil.Infer())
+		return err
+	}); err != nil {
+		log.Fatalf("update contact: %v", err)
+	}
+	fmt.Printf("Linked contact %d to %s (org %d)\n", id, names[c.Org], c.Org)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runContactDupes(cmd *cobra.Command, args []string) {
+	rows, err := db.Conn.QueryContext(context.Background(), `
+		SELECT LOWER(TRIM(email)) AS addr, GROUP_CONCAT(id, ',')
+		FROM (SELECT id, email FROM contacts ORDER BY id)
+		WHERE TRIM(COALESCE(email, '')) <> ''
+		GROUP BY addr
+		HAVING COUNT(*) > 1
+		ORDER BY addr`)
+	if err != nil {
+		log.Fatalf("query duplicate emails: %v", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var addr, ids string
+		if err := rows.Scan(&addr, &ids); err != nil {
+			log.Fatalf("scan duplicate emails: %v", err)
+		}
+		fmt.Printf("%s\t%s\n", addr, ids)
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatalf("query duplicate emails: %v", err)
+	}
+	fmt.Println(countLabel(n, "duplicate email"))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// contactFields builds the wizard fields shared by add & edit, seeded from c
+func contactFields(c *models.Contact) []Field {
+	return []Field{
 		{
-			Label:   "Organization ID",
-			Initial: strconv.FormatInt(c.Org, 10),
+			Label:    "Organization ID",
+			Initial:  formatID(c.Org),
+			Hint:     "numeric ID, see: zenith org list",
+			Required: true,
 			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return int64(0), nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return i, nil
+				return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
 			},
+			Validate: validateRef("org", models.OrgExists),
 			Assign: func(holder any, v any) {
 				rv := reflect.ValueOf(holder).Elem()
 				fv := rv.FieldByName("Org")
@@ -213,12 +317,10 @@ func runContactEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 		{
-			Label:   "Name",
-			Initial: c.Name,
+			Label:    "Name",
+			Initial:  c.Name,
+			Required: true,
 			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("name cannot be blank")
-				}
 				return s, nil
 			},
 			Assign: func(holder any, v any) {
@@ -242,8 +344,24 @@ func runContactEdit(cmd *cobra.Command, args []string) {
 		{
 			Label:   "Email (optional)",
 			Initial: c.Email.String,
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
+			Hint:    "e.g. ann@example.com",
+			Parse:   parseEmail,
+			Validate: func(holder any, raw string) error {
+				if strings.TrimSpace(raw) == "" {
+					return nil
+				}
+				// same normalization as contact dupes; the contact itself is excluded for edit
+				taken, err := models.Contacts(
+					qm.Where("LOWER(TRIM(email)) = LOWER(TRIM(?))", raw),
+					qm.Where("id IS NOT ?", holder.(*models.Contact).ID),
+				).Exists(context.Background(), db.Conn)
+				if err != nil {
+					return fmt.Errorf("check email: %w", err)
+				}
+				if taken {
+					return fmt.Errorf("another contact already uses %s", strings.TrimSpace(raw))
+				}
+				return nil
 			},
 			Assign: func(holder any, v any) {
 				reflect.ValueOf(holder).Elem().
@@ -254,23 +372,92 @@ func runContactEdit(cmd *cobra.Command, args []string) {
 		{
 			Label:   "LinkedIn (optional)",
 			Initial: c.Linkedin.String,
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
+			Hint:    "profile URL, e.g. https://www.linkedin.com/in/ann",
+			Parse:   parseLinkedIn,
 			Assign: func(holder any, v any) {
 				reflect.ValueOf(holder).Elem().
 					FieldByName("Linkedin").
 					Set(reflect.ValueOf(v))
 			},
 		},
+		{
+			Label:   "Phone (optional)",
+			Initial: c.Phone.String,
+			Hint:    "international format, e.g. +44 20 7946 0958",
+			Parse:   parsePhone,
+			Assign: func(holder any, v any) {
+				reflect.ValueOf(holder).Elem().
+					FieldByName("Phone").
+					Set(reflect.ValueOf(v))
+			},
+		},
 	}
+}
 
-	RunFormWizard(fields, c)
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	if _, err := c.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("update contact: %v", err)
+// parseEmail accepts a bare address such as ann@example.com; blank is null
+func parseEmail(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return null.String{}, nil
 	}
-	fmt.Printf("Updated contact %d\n", c.ID.Int64)
+	// ParseAddress also takes "Ann <ann@example.com>", which is not a bare address
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Address != s {
+		return nil, fmt.Errorf("invalid email %q", s)
+	}
+	return null.StringFrom(s), nil
+}
+
+// parseLinkedIn accepts a URL on linkedin.com or a subdomain, adding https://
+// when the scheme is left out; blank is null
+func parseLinkedIn(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return null.String{}, nil
+	}
+	full := s
+	if !strings.Contains(full, "://") {
+		full = "https://" + full
+	}
+	u, err := url.Parse(full)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("invalid LinkedIn URL %q", s)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return nil, fmt.Errorf("%q is not a linkedin.com URL", s)
+	}
+	return null.StringFrom(full), nil
+}
+
+// parsePhone strips spaces, dashes, dots & parentheses, keeping an optional
+// leading +, and accepts 7 to 15 digits as E.164 allows
+func parsePhone(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return null.String{}, nil
+	}
+
+	var b strings.Builder
+	digits := 0
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ', r == '-', r == '.', r == '(', r == ')':
+		default:
+			return nil, fmt.Errorf("unexpected %q in phone number", r)
+		}
+	}
+	if digits < 7 || digits > 15 {
+		return nil, fmt.Errorf("phone number needs 7-15 digits, got %d", digits)
+	}
+	return null.StringFrom(b.String()), nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,139 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintain the database file",
+}
+
+var dbMoveCmd = &cobra.Command{
+	Use:   "move [dest]",
+	Short: "Move the database and its -wal/-shm files together",
+	Long: `Checkpoint the write-ahead log, then move the database selected by --db
+(or the configured db-path) to dest, carrying its -wal and -shm sidecar files
+along. dest may be a file path or an existing directory. When the database
+came from db-path in config.toml, that entry is rewritten to the new location.`,
+	Example: `  zenith db move ~/Documents/zenith.db
+  zenith db move --db old.db archive/`,
+	Args: cobra.ExactArgs(1),
+	Run:  runDBMove,
+}
+
+// dbSidecars are the suffixes sqlite appends for files that belong to a database
+var dbSidecars = []string{"-wal", "-shm"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(dbCmd)
+	dbCmd.AddCommand(dbMoveCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runDBMove(cmd *cobra.Command, args []string) {
+	src := dbPath
+	if _, err := os.Stat(src); err != nil {
+		log.Fatalf("db move: %v", err)
+	}
+
+	dest := args[0]
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, filepath.Base(src))
+	}
+	if _, err := os.Stat(dest); !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("db move: %s already exists", dest)
+	}
+
+	// fold the WAL into the main file so the sidecars are empty when moved
+	conn, err := db.Open(src)
+	if err != nil {
+		log.Fatalf("db move: %v", err)
+	}
+	if _, err := conn.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		conn.Close()
+		log.Fatalf("db move: checkpoint: %v", err)
+	}
+	if err := conn.Close(); err != nil {
+		log.Fatalf("db move: close: %v", err)
+	}
+
+	if err := os.Rename(src, dest); err != nil {
+		log.Fatalf("db move: %v", err)
+	}
+	for _, suffix := range dbSidecars {
+		err := os.Rename(src+suffix, dest+suffix)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("db move: %s moved but %s was not: %v", src, src+suffix, err)
+		}
+	}
+	fmt.Printf("moved %s → %s\n", src, dest)
+
+	// only follow the move in config when config chose this database
+	if rootCmd.PersistentFlags().Changed("db") || !viper.IsSet("db-path") {
+		return
+	}
+	if err := updateConfigDBPath(dest); err != nil {
+		log.Fatalf("db move: database moved but config not updated: %v", err)
+	}
+	fmt.Printf("updated db-path in %s\n", viper.ConfigFileUsed())
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// configDBPathLine matches the db-path assignment in config.toml
+var configDBPathLine = regexp.MustCompile(`(?m)^(\s*db-path\s*=\s*).*$`)
+
+// updateConfigDBPath rewrites db-path in place, keeping the file's comments & layout
+func updateConfigDBPath(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	name := viper.ConfigFileUsed()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if !configDBPathLine.Match(data) {
+		return fmt.Errorf("no db-path line in %s", name)
+	}
+	data = configDBPathLine.ReplaceAll(data, []byte(fmt.Sprintf("${1}%q", abs)))
+	return os.WriteFile(name, data, 0o644)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,197 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [db-a] [db-b]",
+	Short: "Compare two database files row by row",
+	Long: `Open two database files read-only and report, per table, which rows
+were added, removed or changed going from db-a to db-b. Rows are matched
+by id; changed rows list the columns that differ.`,
+	Example: `  zenith diff backup/zenith-yesterday.db zenith.db`,
+	Args:    cobra.ExactArgs(2),
+	Run:     runDiff,
+}
+
+// diffTables are the id-keyed tables compared by diff
+var diffTables = []string{"orgs", "contacts", "events", "tasks"}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runDiff(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	a, err := db.OpenReadOnly(args[0])
+	if err != nil {
+		log.Fatalf("diff %s: %v", args[0], err)
+	}
+	defer a.Close()
+	b, err := db.OpenReadOnly(args[1])
+	if err != nil {
+		log.Fatalf("diff %s: %v", args[1], err)
+	}
+	defer b.Close()
+
+	for _, table := range diffTables {
+		before, err := loadRows(ctx, a, table)
+		if err != nil {
+			log.Fatalf("diff %s in %s: %v", table, args[0], err)
+		}
+		after, err := loadRows(ctx, b, table)
+		if err != nil {
+			log.Fatalf("diff %s in %s: %v", table, args[1], err)
+		}
+		printTableDiff(table, before, after)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tableRows holds a table's columns and its rows by id, values rendered as
+// text; Valid is kept so NULL and "" still compare as different
+type tableRows struct {
+	cols []string
+	rows map[int64]map[string]sql.NullString
+}
+
+// loadRows reads every row of table, rendering values as text for comparison
+func loadRows(ctx context.Context, conn *sql.DB, table string) (tableRows, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT * FROM "+table+" ORDER BY id")
+	if err != nil {
+		return tableRows{}, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return tableRows{}, err
+	}
+
+	out := tableRows{cols: cols, rows: map[int64]map[string]sql.NullString{}}
+	vals := make([]sql.NullString, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return tableRows{}, err
+		}
+		row := make(map[string]sql.NullString, len(cols))
+		var id int64
+		for i, col := range cols {
+			row[col] = vals[i]
+			if col == "id" {
+				fmt.Sscan(vals[i].String, &id)
+			}
+		}
+		out.rows[id] = row
+	}
+	return out, rows.Err()
+}
+
+// printTableDiff prints a one-line summary for table, then the affected ids
+func printTableDiff(table string, before, after tableRows) {
+	// only columns both sides share are compared; schema drift is reported once
+	var shared, dropped, gained []string
+	for _, col := range after.cols {
+		if slices.Contains(before.cols, col) {
+			shared = append(shared, col)
+		} else {
+			gained = append(gained, col)
+		}
+	}
+	for _, col := range before.cols {
+		if !slices.Contains(after.cols, col) {
+			dropped = append(dropped, col)
+		}
+	}
+
+	var added, removed, changed []string
+	for id, row := range after.rows {
+		old, ok := before.rows[id]
+		if !ok {
+			added = append(added, fmt.Sprint(id))
+			continue
+		}
+		var cols []string
+		for _, col := range shared {
+			if old[col] != row[col] {
+				cols = append(cols, col)
+			}
+		}
+		if len(cols) > 0 {
+			changed = append(changed, fmt.Sprintf("%d (%s)", id, strings.Join(cols, ", ")))
+		}
+	}
+	for id := range before.rows {
+		if _, ok := after.rows[id]; !ok {
+			removed = append(removed, fmt.Sprint(id))
+		}
+	}
+
+	fmt.Printf("%s: %d added, %d removed, %d changed\n", table, len(added), len(removed), len(changed))
+	if len(gained) > 0 {
+		fmt.Printf("  columns added: %s\n", strings.Join(gained, ", "))
+	}
+	if len(dropped) > 0 {
+		fmt.Printf("  columns dropped: %s\n", strings.Join(dropped, ", "))
+	}
+	for _, group := range []struct {
+		mark string
+		ids  []string
+	}{{"+", added}, {"-", removed}, {"~", changed}} {
+		slices.SortFunc(group.ids, compareLeadingID)
+		for _, id := range group.ids {
+			fmt.Printf("  %s %s\n", group.mark, id)
+		}
+	}
+}
+
+// compareLeadingID orders "12" / "12 (name)" entries numerically by id
+func compareLeadingID(x, y string) int {
+	var a, b int64
+	fmt.Sscan(x, &a)
+	fmt.Sscan(y, &b)
+	return cmp.Compare(a, b)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,334 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump [file]",
+	Short: "Back up the whole database into a single archive",
+	Long: `Write a consistent snapshot of the database into one zip archive holding
+the sqlite file and a manifest.json with the schema version and row counts.
+The archive is written next to its destination and renamed into place, so an
+interrupted dump never leaves a partial file. An existing file is not
+overwritten. Restore it with: zenith restore <file>`,
+	Example: `  zenith dump backup.zenith
+  zenith dump --db work.db ~/backups/work-$(date +%F).zenith`,
+	Args:              cobra.ExactArgs(1),
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runDump,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Short: "Recreate a database from a dump archive",
+	Long: `Replace the database selected by --db (or db-path) with the snapshot in a
+zenith dump archive. The target must hold no orgs, contacts, events or tasks,
+and the archive's schema version must match the one this zenith migrates to.
+The snapshot is checked and then renamed over the target in one step.`,
+	Example: `  zenith restore backup.zenith
+  zenith restore --db fresh.db backup.zenith`,
+	Args:              cobra.ExactArgs(1),
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runRestore,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	dumpFormat   = 1 // bump when the archive layout changes
+	dumpManifest = "manifest.json"
+	dumpDatabase = "zenith.db"
+)
+
+// dumpTables are the tables counted in the manifest and checked empty on restore
+var dumpTables = []string{"orgs", "contacts", "events", "tasks"}
+
+// manifest describes a dump archive
+type manifest struct {
+	Format        int              `json:"format"`
+	SchemaVersion uint             `json:"schema_version"`
+	Created       time.Time        `json:"created"`
+	Rows          map[string]int64 `json:"rows"`
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(dumpCmd, restoreCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runDump(cmd *cobra.Command, args []string) {
+	dest := args[0]
+	if _, err := os.Stat(dest); !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("dump: %s already exists", dest)
+	}
+	ctx := context.Background()
+
+	version, err := schemaVersion(db.Conn)
+	if err != nil {
+		log.Fatalf("dump: %v", err)
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(dest), ".zenith-dump-")
+	if err != nil {
+		log.Fatalf("dump: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	snapshot := filepath.Join(tmp, dumpDatabase)
+	if err := db.Backup(ctx, snapshot); err != nil {
+		log.Fatalf("dump: snapshot: %v", err)
+	}
+	// count the snapshot itself, so the manifest matches the archived file
+	// even when rows were written between the backup and here
+	rows, err := snapshotRowCounts(ctx, snapshot)
+	if err != nil {
+		log.Fatalf("dump: snapshot: %v", err)
+	}
+
+	m := manifest{Format: dumpFormat, SchemaVersion: version, Created: time.Now().UTC(), Rows: rows}
+	archive := filepath.Join(tmp, "archive.zip")
+	if err := writeDumpArchive(archive, m, snapshot); err != nil {
+		log.Fatalf("dump: %v", err)
+	}
+	if err := os.Rename(archive, dest); err != nil {
+		log.Fatalf("dump: %v", err)
+	}
+
+	fmt.Printf("dumped %s (schema %d", dest, version)
+	for _, t := range dumpTables {
+		fmt.Printf(", %s %s", humanCount(int(rows[t])), t)
+	}
+	fmt.Println(")")
+}
+
+// writeDumpArchive zips the manifest and the snapshot file into name
+func writeDumpArchive(name string, m manifest, snapshot string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	entry := func(name string) (io.Writer, error) {
+		return zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: m.Created})
+	}
+
+	w, err := entry(dumpManifest)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return err
+	}
+
+	src, err := os.Open(snapshot)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if w, err = entry(dumpDatabase); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runRestore(cmd *cobra.Command, args []string) {
+	zr, err := zip.OpenReader(args[0])
+	if err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	defer zr.Close()
+
+	var m manifest
+	if err := readDumpManifest(&zr.Reader, &m); err != nil {
+		log.Fatalf("restore: %s: %v", args[0], err)
+	}
+	if m.Format != dumpFormat {
+		log.Fatalf("restore: unsupported archive format %d (expected %d)", m.Format, dumpFormat)
+	}
+
+	// the target was just opened & migrated, so its version is this zenith's
+	ctx := context.Background()
+	version, err := schemaVersion(db.Conn)
+	if err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	if m.SchemaVersion != version {
+		log.Fatalf("restore: archive has schema version %d, this zenith uses %d", m.SchemaVersion, version)
+	}
+	rows, err := tableRowCounts(ctx, db.Conn)
+	if err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	for _, t := range dumpTables {
+		if rows[t] > 0 {
+			log.Fatalf("restore: %s is not empty (%d %s); restore into a new database with --db", dbPath, rows[t], t)
+		}
+	}
+
+	// unpack beside the target so the final rename stays on one filesystem
+	tmp, err := os.CreateTemp(filepath.Dir(dbPath), ".zenith-restore-*.db")
+	if err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := extractDumpDatabase(&zr.Reader, tmp); err != nil {
+		tmp.Close()
+		log.Fatalf("restore: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+	if err := checkSnapshot(tmp.Name()); err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+
+	// release the target before replacing it
+	if err := db.Conn.Close(); err != nil {
+		log.Fatalf("restore: close: %v", err)
+	}
+	db.Conn = nil
+	for _, suffix := range dbSidecars {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Fatalf("restore: %v", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), dbPath); err != nil {
+		log.Fatalf("restore: %v", err)
+	}
+
+	fmt.Printf("restored %s from %s (dumped %s)\n", dbPath, args[0], humanDateTime(m.Created))
+}
+
+// readDumpManifest decodes the archive's manifest.json into m
+func readDumpManifest(zr *zip.Reader, m *manifest) error {
+	f, err := zr.Open(dumpManifest)
+	if err != nil {
+		return fmt.Errorf("not a zenith dump: %w", err)
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(m)
+}
+
+// extractDumpDatabase copies the archived sqlite file into w
+func extractDumpDatabase(zr *zip.Reader, w io.Writer) error {
+	f, err := zr.Open(dumpDatabase)
+	if err != nil {
+		return fmt.Errorf("not a zenith dump: %w", err)
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// checkSnapshot runs sqlite's quick_check over an unpacked snapshot
+func checkSnapshot(path string) error {
+	conn, err := db.OpenReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var result string
+	if err := conn.QueryRow("PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("check snapshot: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("snapshot is corrupt: %s", result)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// snapshotRowCounts counts a written snapshot's tables in one read transaction
+func snapshotRowCounts(ctx context.Context, path string) (map[string]int64, error) {
+	conn, err := db.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return tableRowCounts(ctx, tx)
+}
+
+// schemaVersion reads the applied migration version of conn
+func schemaVersion(conn *sql.DB) (uint, error) {
+	m, err := db.NewMigrator(conn)
+	if err != nil {
+		return 0, err
+	}
+	return db.Version(m)
+}
+
+// tableRowCounts counts the rows of every dumpTables table
+func tableRowCounts(ctx context.Context, conn boil.ContextExecutor) (map[string]int64, error) {
+	rows := make(map[string]int64, len(dumpTables))
+	for _, t := range dumpTables {
+		var n int64
+		if err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+t).Scan(&n); err != nil {
+			return nil, fmt.Errorf("count %s: %w", t, err)
+		}
+		rows[t] = n
+	}
+	return rows, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -23,6 +23,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"reflect"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/DanielRivasMD/Zenith/db"
 	"github.com/DanielRivasMD/Zenith/models"
@@ -44,7 +46,6 @@ var eventCmd = &cobra.Command{
 	Use:               "event",
 	Short:             "Manage events",
 	Long:              helpEvent,
-	Example:           exampleEvent,
 	PersistentPreRun:  persistentPreRun,
 	PersistentPostRun: persistentPostRun,
 }
@@ -62,161 +63,89 @@ var eventEditCmd = &cobra.Command{
 	Run:   runEventEdit,
 }
 
+var eventLikeID int64 // --like: seed the add form from an existing event
+
+// eventPriorityMax is the highest priority the event form accepts; 0 is the lowest
+const eventPriorityMax = 5
+
+// eventColumns lists the events table columns in schema order
+var eventColumns = []string{
+	"id", "contact", "occurred", "mode", "priority",
+	"context", "description", "action", "comment", "author", "created", "updated",
+}
+
 func init() {
 	rootCmd.AddCommand(eventCmd)
 
 	// list & rm are wired up generically
 	RegisterCrudSubcommands(eventCmd, "", CrudModel[*models.Event]{
-		Singular: "event",
-		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Event, error) {
-			return models.Events(qm.OrderBy("id ASC")).All(ctx, conn)
+		Singular:     "event",
+		Columns:      eventColumns,
+		DateColumn:   "occurred",
+		DefaultOrder: "occurred DESC",
+		ListFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) ([]*models.Event, error) {
+			return models.Events(mods...).All(ctx, conn)
+		},
+		CountFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) (int64, error) {
+			return models.Events(mods...).Count(ctx, conn)
 		},
 		Format: func(e *models.Event) (int64, string) {
 			// ID is null.Int64, Occurred is time.Time, Mode is null.String
-			return e.ID.Int64, fmt.Sprintf("%s at %s", e.Mode.String, e.Occurred.Format("2006-01-02 15:04"))
+			return e.ID.Int64, fmt.Sprintf("%s at %s", e.Mode.String, humanDateTime(e.Occurred))
 		},
-		RemoveFn: func(ctx context.Context, conn *sql.DB, id int64) error {
-			e, err := models.FindEvent(ctx, conn, null.Int64From(id))
+		Age: func(e *models.Event) time.Time {
+			return e.Occurred
+		},
+		RemoveFn: func(ctx context.Context, exec boil.ContextExecutor, id int64) (int64, error) {
+			return models.Events(qm.Where("id = ?", id)).DeleteAll(ctx, exec)
+		},
+		ShowFn: func(ctx context.Context, conn *sql.DB, id int64) (string, error) {
+			e, err := db.Found(models.FindEvent(ctx, conn, null.Int64From(id)))
 			if err != nil {
-				return err
+				return "", err
 			}
-			_, err = e.Delete(ctx, conn)
-			return err
+			attendees, err := eventAttendees(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			out := formatRecord(eventColumns, eventRecord(e)) + "attendees:"
+			if len(attendees) == 0 {
+				out += " (none)"
+			}
+			for _, c := range attendees {
+				out += fmt.Sprintf("\n  %d\t%s", c.ID.Int64, c.Name)
+			}
+			return out + "\n", nil
 		},
 	})
 
 	eventCmd.AddCommand(eventAddCmd, eventEditCmd)
+	eventAddCmd.Flags().Int64Var(&eventLikeID, "like", 0, "Prefill the form from an existing event ID")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runEventAdd(cmd *cobra.Command, args []string) {
-	e := &models.Event{}
-
-	fields := []Field{
-		{
-			Label:   "Contact ID",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return int64(0), nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return i, nil
-			},
-			Assign: func(holder any, v any) {
-				rv := reflect.ValueOf(holder).Elem()
-				fv := rv.FieldByName("Contact")
-				if !fv.IsValid() || !fv.CanSet() {
-					log.Fatalf("cannot set Contact on %T", holder)
-				}
-				fv.SetInt(v.(int64))
-			},
-		},
-		{
-			Label:   "Occurred At (YYYY-MM-DD HH:MM)",
-			Initial: time.Now().Format("2006-01-02 15:04"),
-			Parse: func(s string) (any, error) {
-				t, err := time.Parse("2006-01-02 15:04", s)
-				if err != nil {
-					return nil, err
-				}
-				return t, nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Occurred").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Mode",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Mode").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Priority (integer)",
-			Initial: "0",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return null.Int64{}, nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return null.Int64From(i), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Priority").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Context (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Context").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Description (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Description").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Action (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Action").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Comment (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Comment").
-					Set(reflect.ValueOf(v))
-			},
-		},
+	e := &models.Event{
+		Occurred: time.Now(),
+		Priority: null.Int64From(0),
 	}
+	if eventLikeID != 0 {
+		e = copyRecord("event", eventLikeID, models.FindEvent)
+	}
+	// the new entry is logged by whoever adds it, even when copied
+	e.Author = null.StringFrom(defaultAuthor())
 
-	RunFormWizard(fields, e)
-
-	if err := e.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("insert event: %v", err)
+	saved := RunFormWizardWithSubmit("event-add", eventFields(e), e, eventWarning, func(holder any) error {
+		if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+			return e.Insert(context.Background(), exec, boil.Infer())
+		}); err != nil {
+			return fmt.Errorf("insert event: %w", err)
+		}
+		return nil
+	})
+	if !saved {
+		return
 	}
 	fmt.Printf("Created event %d\n", e.ID.Int64)
 }
@@ -229,25 +158,61 @@ func runEventEdit(cmd *cobra.Command, args []string) {
 		log.Fatalf("invalid event ID %q: %v", args[0], err)
 	}
 
-	e, err := models.FindEvent(context.Background(), db.Conn, null.Int64From(idNum))
+	e, err := db.Found(models.FindEvent(context.Background(), db.Conn, null.Int64From(idNum)))
 	if err != nil {
-		log.Fatalf("find event: %v", err)
+		fatalFind("event", idNum, err)
 	}
 
-	fields := []Field{
+	saved := RunFormWizardWithSubmit(fmt.Sprintf("event-edit-%d", idNum), eventFields(e), e, eventWarning, func(holder any) error {
+		if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+			_, err := e.Update(context.Background(), exec, boil.Infer())
+			return err
+		}); err != nil {
+			return fmt.Errorf("update event: %w", err)
+		}
+		return nil
+	})
+	if !saved {
+		return
+	}
+	fmt.Printf("Updated event %d\n", e.ID.Int64)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// defaultAuthor prefills event authorship from the config "author" key, falling back to $USER
+func defaultAuthor() string {
+	if a := os.ExpandEnv(viper.GetString("author")); a != "" {
+		return a
+	}
+	return os.Getenv("USER")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// eventWarning flags allowed but unusual events before they are saved
+func eventWarning(holder any) string {
+	e := holder.(*models.Event)
+	if strings.EqualFold(e.Mode.String, "note") && e.Priority.Int64 >= 5 {
+		return fmt.Sprintf("priority %d is unusually high for a note", e.Priority.Int64)
+	}
+	return ""
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// eventFields builds the wizard fields shared by add & edit, seeded from e
+func eventFields(e *models.Event) []Field {
+	return []Field{
 		{
-			Label:   "Contact ID",
-			Initial: strconv.FormatInt(e.Contact, 10),
+			Label:    "Contact ID",
+			Initial:  formatID(e.Contact),
+			Hint:     "numeric ID, see: zenith contact list",
+			Required: true,
 			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return int64(0), nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return i, nil
+				return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
 			},
+			Validate: validateRef("contact", models.ContactExists),
 			Assign: func(holder any, v any) {
 				rv := reflect.ValueOf(holder).Elem()
 				fv := rv.FieldByName("Contact")
@@ -258,10 +223,12 @@ func runEventEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 		{
-			Label:   "Occurred At (YYYY-MM-DD HH:MM)",
+			Label:   "Occurred At",
 			Initial: e.Occurred.Format("2006-01-02 15:04"),
+			Hint:    "YYYY-MM-DD HH:MM, e.g. 2025-01-02 15:04, or epoch seconds",
+			Help:    "24-hour clock, read as UTC",
 			Parse: func(s string) (any, error) {
-				t, err := time.Parse("2006-01-02 15:04", s)
+				t, err := parseTimeOrEpoch(s, "2006-01-02 15:04")
 				if err != nil {
 					return nil, err
 				}
@@ -286,15 +253,16 @@ func runEventEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 		{
-			Label:   "Priority (integer)",
-			Initial: strconv.FormatInt(e.Priority.Int64, 10),
+			Label:   "Priority",
+			Initial: formatNullInt(e.Priority),
+			Hint:    fmt.Sprintf("allowed: 0-%d, blank for none", eventPriorityMax),
 			Parse: func(s string) (any, error) {
 				if strings.TrimSpace(s) == "" {
 					return null.Int64{}, nil
 				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
+				i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+				if err != nil || i < 0 || i > eventPriorityMax {
+					return nil, fmt.Errorf("priority must be a whole number from 0 to %d", eventPriorityMax)
 				}
 				return null.Int64From(i), nil
 			},
@@ -352,14 +320,19 @@ func runEventEdit(cmd *cobra.Command, args []string) {
 					Set(reflect.ValueOf(v))
 			},
 		},
+		{
+			Label:   "Author (optional)",
+			Initial: e.Author.String,
+			Parse: func(s string) (any, error) {
+				return null.StringFrom(s), nil
+			},
+			Assign: func(holder any, v any) {
+				reflect.ValueOf(holder).Elem().
+					FieldByName("Author").
+					Set(reflect.ValueOf(v))
+			},
+		},
 	}
-
-	RunFormWizard(fields, e)
-
-	if _, err := e.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("update event: %v", err)
-	}
-	fmt.Printf("Updated event %d\n", e.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -21,12 +21,16 @@ package cmd
 import (
 	"context"
 	"database/sql"
-	"encoding/csv"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/queries"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/spf13/cobra"
 
@@ -39,240 +43,570 @@ import (
 // TODO: format cmd
 // TODO: add completions for tables
 var (
-	exportAll bool
+	exportAll        bool
+	exportFormat     string
+	exportOutDir     string
+	exportGzip       bool
+	exportPageSize   int
+	exportQuery      queryFlags
+	exportSinceID    int64 // --since-id: only ids above this, when set
+	exportSinceIDSet bool
+	exportNoEmpty    bool
+	exportAppend     bool
+	exportBOM        bool
+	exportContinue   bool
+	exportQuoteAll   bool
+	exportQuiet      bool
+	exportLineEnding string
+	exportResolve    bool
+	exportNested     bool
+	exportRedact     []string          // --redact column[=transform]
+	exportMasks      map[string]string // column → transform, parsed from exportRedact
+	exportCompute    []string          // --compute name=fn(column)
+	exportComputed   []computedColumn  // parsed from exportCompute
+	exportColumns    []string          // --columns: keep only these
+	exportExclude    []string          // --exclude-columns: drop these
 
 	exportCmd = &cobra.Command{
 		Use:   "export [tables...]",
-		Short: "Export one or more tables to CSV files",
-		Long: `Export specified tables from the database into CSV files in the
-current working directory. Supported table names:
+		Short: "Export one or more tables to CSV or JSON files",
+		Long: `Export specified tables from the database into CSV (default) or JSON
+files in the current working directory, or under --out-dir, which is created
+if missing. Supported table names:
 
   orgs, contacts, events, tasks
 
-Use --all to export every supported table.`,
+plus "joined": one denormalized file with every task next to its event,
+contact and organization (left joins, so unlinked tasks keep empty cells),
+columns prefixed by table: task_title, event_occurred, contact_name,
+org_name, ... Query flags use those names, e.g. --where org_name=Acme;
+dates filter on task_duedate. It is not part of --all.
+
+Run "zenith tables" to see how many rows each holds. Use --all to export every supported table. The --where, --sort, --since,
+--until, --last, --next and --limit flags behave exactly as on the list
+subcommands.
+
+--since and --until (YYYY-MM-DD, both inclusive) bound each table by its own
+date: occurred for events, duedate for tasks, created for orgs & contacts.
+To back up one quarter of events:
+
+  zenith export events --since 2025-01-01 --until 2025-03-31
+
+JSON output is an array of objects keyed by column, with values as strings
+exactly like the CSV cells. --page-size splits it into numbered files
+(events.0001.json, events.0002.json, ...) of at most N records each.
+
+--quote-all wraps every CSV field in double quotes, not only those that
+need it, for strict downstream parsers.
+
+--since-id N exports only rows with an id above N, for incremental syncs
+into append-only stores. Each table then reports the highest id it wrote
+("events: max id 5123", even under --quiet), to pass to the next run;
+with no new rows it repeats N.
+
+--line-ending crlf ends every line with CR LF instead of LF, in CSV and
+JSON alike, for importers on Windows that require it.
+
+--gzip compresses every file it writes, appending .gz to its name
+(organizations.csv.gz, events.0001.json.gz). It cannot be combined with
+--append, which needs to read the previous file back.
+
+--bom prefixes each CSV file with a UTF-8 byte-order mark so Excel detects
+the encoding and shows accented names correctly.
+
+--append merges into an existing JSON file instead of overwriting it:
+rows already present are replaced by id and new ones are added at the end,
+so repeated runs build up a cumulative dataset.
+
+--redact column[=transform] rewrites that column in every exported table
+before writing, for sharing data externally. Transforms: mask-email
+(default, j***@acme.com), hash (short sha256) and truncate (first 4 chars).
+
+--compute name=fn(column) appends a derived column to every table that has
+the input column. Functions: days_since (whole days from a date until now),
+upper, lower and length. Redaction runs after, so it can mask either.
+
+--columns keeps only the listed columns and --exclude-columns drops them;
+the two cannot be combined. Either applies last, after --resolve, --compute
+and --redact, so derived columns can be selected too. Columns keep their
+table order, names a table lacks are skipped for it, and a name unknown to
+every table is an error. --append needs id, so it cannot be left out.
+
+By default the first failing table aborts the export; --continue-on-error
+reports it and moves on to the next, exiting non-zero once all are done.
+
+--resolve adds the name behind each foreign key as a flat column: org_name
+on contacts, contact_name on events. With --format json, --nested instead
+replaces the key with an object, e.g. "org": {"id": "1", "name": "Acme"}.
+
+On a terminal a progress line tracks rows written for each file; --quiet
+hides it along with the "exported <file>" lines.
+
+--fail-on-empty still writes the files but exits non-zero when any
+requested table had no rows, so scheduled backups can alert on missing data.`,
 		Example: `  zenith export orgs
   zenith export contacts events
-  zenith export --all`,
+  zenith export --all
+  zenith export --all --out-dir backups/$(date +%F)
+  zenith export --all --gzip
+  zenith export joined --sort contact_name
+  zenith export events --format json --page-size 1000
+  zenith export --all --fail-on-empty
+  zenith export events --format json --last 1d --append
+  zenith export contacts --redact email --redact phone=hash
+  zenith export orgs --compute 'age_days=days_since(created)'
+  zenith export events --since-id 5000
+  zenith export contacts --bom --line-ending crlf
+  zenith export contacts --columns id,name,email
+  zenith export --all --exclude-columns created,updated`,
 		PersistentPreRun:  persistentPreRun,
 		PersistentPostRun: persistentPostRun,
 		Args:              cobra.ArbitraryArgs,
-		Run:               runExport,
+		SilenceUsage:      true, // failures are about data, not flags
+		RunE:              runExport,
 	}
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// exportTables are the table names export accepts; orgs may also be spelled
+// organizations, and --all covers every one but joined
+var exportTables = []string{"orgs", "contacts", "events", "tasks", "joined"}
+
 func init() {
 	rootCmd.AddCommand(exportCmd)
 	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Export all supported tables")
+	exportCmd.Flags().BoolVarP(&exportQuiet, "quiet", "q", false, "Suppress progress and the per-file summary")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "Output format: csv or json")
+	exportCmd.Flags().StringVarP(&exportOutDir, "out-dir", "o", "", "Directory to write files into (default: current directory)")
+	exportCmd.Flags().IntVar(&exportPageSize, "page-size", 0, "Split JSON output into files of at most N records")
+	exportCmd.Flags().BoolVar(&exportQuoteAll, "quote-all", false, "Quote every CSV field, not only those that need it")
+	exportCmd.Flags().BoolVar(&exportGzip, "gzip", false, "Compress each file with gzip, adding .gz to its name")
+	exportCmd.Flags().StringVar(&exportLineEnding, "line-ending", "lf", "Line terminator: lf or crlf")
+	exportCmd.Flags().BoolVar(&exportBOM, "bom", false, "Start CSV files with a UTF-8 byte-order mark (for Excel)")
+	exportCmd.Flags().StringArrayVar(&exportRedact, "redact", nil, "Redact column[=mask-email|hash|truncate] (repeatable)")
+	exportCmd.Flags().StringArrayVar(&exportCompute, "compute", nil, "Append column name=fn(column): days_since, upper, lower, length (repeatable)")
+	exportCmd.Flags().StringSliceVar(&exportColumns, "columns", nil, "Export only these columns (comma-separated)")
+	exportCmd.Flags().StringSliceVar(&exportExclude, "exclude-columns", nil, "Export all but these columns (comma-separated)")
+	exportCmd.MarkFlagsMutuallyExclusive("columns", "exclude-columns")
+	exportCmd.Flags().BoolVar(&exportResolve, "resolve", false, "Add org_name / contact_name columns for foreign keys")
+	exportCmd.Flags().BoolVar(&exportNested, "nested", false, "With --resolve and JSON, emit related rows as nested objects")
+	exportCmd.Flags().BoolVar(&exportAppend, "append", false, "Merge into an existing JSON file, de-duplicating by id")
+	exportCmd.Flags().BoolVar(&exportContinue, "continue-on-error", false, "Keep exporting remaining tables after one fails")
+	exportCmd.Flags().BoolVar(&exportNoEmpty, "fail-on-empty", false, "Exit non-zero if a requested table has no rows")
+	exportCmd.MarkFlagsMutuallyExclusive("gzip", "append")
+	registerQueryFlags(exportCmd, &exportQuery)
+	exportCmd.Flags().Int64Var(&exportSinceID, "since-id", 0, "Only rows with an id greater than N; prints the max id exported")
+
+	// --sort applies to every exported table, so offer the columns they all share
+	var shared []string
+	for _, col := range orgColumns {
+		if slices.Contains(contactColumns, col) && slices.Contains(eventColumns, col) && slices.Contains(taskColumns, col) {
+			shared = append(shared, col)
+		}
+	}
+	registerSortCompletion(exportCmd, shared)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func runExport(cmd *cobra.Command, args []string) {
+func runExport(cmd *cobra.Command, args []string) error {
+	switch exportFormat {
+	case "csv":
+		if exportPageSize > 0 {
+			return fmt.Errorf("--page-size requires --format json")
+		}
+		if exportAppend {
+			return fmt.Errorf("--append requires --format json")
+		}
+		if exportNested {
+			return fmt.Errorf("--nested requires --format json")
+		}
+	case "json":
+		if exportBOM || exportQuoteAll {
+			return fmt.Errorf("--bom and --quote-all only apply to --format csv")
+		}
+		if exportNested && exportAppend {
+			return fmt.Errorf("--nested cannot be combined with --append")
+		}
+		if exportAppend && exportPageSize > 0 {
+			return fmt.Errorf("--append cannot be combined with --page-size")
+		}
+	default:
+		return fmt.Errorf("unknown format %q (valid: csv, json)", exportFormat)
+	}
+
+	exportSinceIDSet = cmd.Flags().Changed("since-id")
+	if exportSinceID < 0 {
+		return fmt.Errorf("--since-id must not be negative")
+	}
+	if exportLineEnding != "lf" && exportLineEnding != "crlf" {
+		return fmt.Errorf("unknown --line-ending %q (valid: lf, crlf)", exportLineEnding)
+	}
+	if exportNested && !exportResolve {
+		return fmt.Errorf("--nested requires --resolve")
+	}
+
+	computed, err := parseComputed(exportCompute)
+	if err != nil {
+		return err
+	}
+	exportComputed = computed
+	masks, err := parseRedactions(exportRedact, computed)
+	if err != nil {
+		return err
+	}
+	exportMasks = masks
+	if err := checkColumnSelection(computed); err != nil {
+		return err
+	}
+
 	// Determine which tables to export
 	if exportAll {
 		args = []string{"orgs", "contacts", "events", "tasks"}
 	}
 	if len(args) == 0 {
-		// return fmt.Errorf("must specify at least one table or use --all")
+		return fmt.Errorf("must specify at least one table or use --all")
+	}
+	// reject typos before any file is written
+	for _, table := range args {
+		if table != "organizations" && !slices.Contains(exportTables, table) {
+			return fmt.Errorf("unknown table %q (valid: %s)", table, strings.Join(exportTables, ", "))
+		}
+	}
+
+	if exportOutDir != "" {
+		if err := os.MkdirAll(exportOutDir, 0o755); err != nil {
+			return fmt.Errorf("create --out-dir: %w", err)
+		}
+	}
+
+	// run exports one table with the shared query flags applied
+	run := func(export func(context.Context, *sql.DB, ...qm.QueryMod) error, columns []string, dateColumn string) error {
+		mods, err := exportQueryMods(columns, dateColumn)
+		if err != nil {
+			return err
+		}
+		return export(cmd.Context(), db.Conn, mods...)
 	}
 
 	// Export each requested table
+	var empty, failed []string
 	for _, table := range args {
+		var err error
 		switch table {
 		case "orgs", "organizations":
-			if err := exportOrgs(cmd.Context(), db.Conn); err != nil {
-				// return err
-			}
+			err = run(exportOrgs, orgColumns, "created")
 		case "contacts":
-			if err := exportContacts(cmd.Context(), db.Conn); err != nil {
-				// return err
-			}
+			err = run(exportContacts, contactColumns, "created")
 		case "events":
-			if err := exportEvents(cmd.Context(), db.Conn); err != nil {
-				// return err
-			}
+			err = run(exportEvents, eventColumns, "occurred")
 		case "tasks":
-			if err := exportTasks(cmd.Context(), db.Conn); err != nil {
-				// return err
+			err = run(exportTasks, taskColumns, "duedate")
+		case "joined":
+			if exportAppend {
+				err = fmt.Errorf("--append does not apply to joined, which has no id column")
+				break
 			}
+			err = run(exportJoined, joinedColumns, "task_duedate")
 		default:
-			// return fmt.Errorf("unknown table %q", table)
+			err = fmt.Errorf("unknown table %q", table)
+		}
+		if errors.Is(err, errEmptyExport) {
+			empty = append(empty, table)
+		} else if err != nil {
+			if !exportContinue {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+			fmt.Fprintf(os.Stderr, "export %s: %v\n", table, err)
+			failed = append(failed, table)
 		}
 	}
 
+	if len(failed) > 0 {
+		return fmt.Errorf("failed tables: %s", strings.Join(failed, ", "))
+	}
+	if len(empty) > 0 {
+		return fmt.Errorf("no rows in %s", strings.Join(empty, ", "))
+	}
+	return nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func exportOrgs(ctx context.Context, conn *sql.DB) error {
-	rows, err := models.Orgs(qm.OrderBy("id ASC")).All(ctx, conn)
+// exportQueryMods applies the shared query flags to one export table;
+// columns[0] is its key, used for the default order & --since-id
+func exportQueryMods(columns []string, dateColumn string) ([]qm.QueryMod, error) {
+	mods, err := buildQueryMods(exportQuery, columns, dateColumn, columns[0]+" ASC")
 	if err != nil {
-		return fmt.Errorf("query organizations: %w", err)
+		return nil, err
 	}
+	if exportSinceIDSet {
+		mods = append(mods, qm.Where(columns[0]+" > ?", exportSinceID))
+	}
+	return mods, nil
+}
 
-	file, err := os.Create("organizations.csv")
-	if err != nil {
-		return fmt.Errorf("create organizations.csv: %w", err)
+// countRows counts the rows q matches, LIMIT included, so a streamed export
+// can show its total before the first page arrives
+func countRows(ctx context.Context, conn *sql.DB, q *queries.Query) (int, error) {
+	query, args := queries.BuildQuery(q)
+	var n int
+	err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+strings.TrimSuffix(query, ";")+")", args...).Scan(&n)
+	return n, err
+}
+
+// fetchPages feeds the rows matching mods to fn in pages of db.PageSize;
+// under --limit the capped result is fetched in one go
+func fetchPages[T any](ctx context.Context, all func(ctx context.Context, mods ...qm.QueryMod) ([]T, error), mods []qm.QueryMod, fn func([]T) error) error {
+	if exportQuery.limit > 0 {
+		rows, err := all(ctx, mods...)
+		if err != nil {
+			return err
+		}
+		return fn(rows)
 	}
-	defer file.Close()
+	return db.Paginate(ctx, func(ctx context.Context, page ...qm.QueryMod) ([]T, error) {
+		return all(ctx, slices.Concat(mods, page)...)
+	}, db.PageSize, fn)
+}
 
-	w := csv.NewWriter(file)
-	defer w.Flush()
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	// header
-	if err := w.Write([]string{"id", "name", "location", "created", "updated"}); err != nil {
+func exportOrgs(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) error {
+	total, err := countRows(ctx, conn, models.Orgs(mods...).Query)
+	if err != nil {
+		return fmt.Errorf("count organizations: %w", err)
+	}
+	out, err := newTableExport("organizations", orgColumns, total)
+	if err != nil {
 		return err
 	}
-
-	// rows
-	for _, o := range rows {
-		record := []string{
-			strconv.FormatInt(o.ID.Int64, 10),
-			o.Name,
-			o.Location.String,
-			o.Created.Format(time.RFC3339),
-			o.Updated.Format(time.RFC3339),
-		}
-		if err := w.Write(record); err != nil {
-			return err
+	defer out.abort()
+	err = fetchPages(ctx, func(ctx context.Context, mods ...qm.QueryMod) ([]*models.Org, error) {
+		return models.Orgs(mods...).All(ctx, conn)
+	}, mods, func(rows []*models.Org) error {
+		records := make([][]string, len(rows))
+		for i, o := range rows {
+			records[i] = orgRecord(o)
 		}
+		return out.write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("query organizations: %w", err)
 	}
 
-	fmt.Println("exported organizations.csv")
-	return nil
+	return out.finish()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func exportContacts(ctx context.Context, conn *sql.DB) error {
-	rows, err := models.Contacts(qm.OrderBy("id ASC")).All(ctx, conn)
-	if err != nil {
-		return fmt.Errorf("query contacts: %w", err)
+func exportContacts(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) error {
+	header := contactColumns
+	if exportResolve {
+		header = append(slices.Clip(header), "org_name")
 	}
 
-	file, err := os.Create("contacts.csv")
+	orgs := newNameLookup("orgs")
+	total, err := countRows(ctx, conn, models.Contacts(mods...).Query)
 	if err != nil {
-		return fmt.Errorf("create contacts.csv: %w", err)
+		return fmt.Errorf("count contacts: %w", err)
 	}
-	defer file.Close()
-
-	w := csv.NewWriter(file)
-	defer w.Flush()
-
-	if err := w.Write([]string{"id", "org", "name", "role", "email", "linkedin", "created", "updated"}); err != nil {
+	out, err := newTableExport("contacts", header, total)
+	if err != nil {
 		return err
 	}
-
-	for _, c := range rows {
-		record := []string{
-			strconv.FormatInt(c.ID.Int64, 10),
-			strconv.FormatInt(c.Org, 10),
-			c.Name,
-			c.Role.String,
-			c.Email.String,
-			c.Linkedin.String,
-			c.Created.Format(time.RFC3339),
-			c.Updated.Format(time.RFC3339),
+	defer out.abort()
+	err = fetchPages(ctx, func(ctx context.Context, mods ...qm.QueryMod) ([]*models.Contact, error) {
+		return models.Contacts(mods...).All(ctx, conn)
+	}, mods, func(rows []*models.Contact) error {
+		records := make([][]string, len(rows))
+		if exportResolve {
+			if err := orgs.load(ctx, conn, collectIDs(rows, func(c *models.Contact) int64 { return c.Org })); err != nil {
+				return err
+			}
 		}
-		if err := w.Write(record); err != nil {
-			return err
+		for i, c := range rows {
+			record := contactRecord(c)
+			if exportResolve {
+				record = append(record, orgs.name(c.Org))
+			}
+			records[i] = record
 		}
+		return out.write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("query contacts: %w", err)
 	}
 
-	fmt.Println("exported contacts.csv")
-	return nil
+	return out.finish()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func exportEvents(ctx context.Context, conn *sql.DB) error {
-	rows, err := models.Events(qm.OrderBy("id ASC")).All(ctx, conn)
-	if err != nil {
-		return fmt.Errorf("query events: %w", err)
+func exportEvents(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) error {
+	header := eventColumns
+	if exportResolve {
+		header = append(slices.Clip(header), "contact_name")
 	}
 
-	file, err := os.Create("events.csv")
+	contacts := newNameLookup("contacts")
+	total, err := countRows(ctx, conn, models.Events(mods...).Query)
 	if err != nil {
-		return fmt.Errorf("create events.csv: %w", err)
-	}
-	defer file.Close()
-
-	w := csv.NewWriter(file)
-	defer w.Flush()
-
-	header := []string{
-		"id", "contact", "occurred", "mode", "priority",
-		"context", "description", "action", "comment", "created", "updated",
+		return fmt.Errorf("count events: %w", err)
 	}
-	if err := w.Write(header); err != nil {
+	out, err := newTableExport("events", header, total)
+	if err != nil {
 		return err
 	}
-
-	for _, i := range rows {
-		record := []string{
-			strconv.FormatInt(i.ID.Int64, 10),
-			strconv.FormatInt(i.Contact, 10),
-			i.Occurred.Format(time.RFC3339),
-			i.Mode.String,
-			strconv.FormatInt(i.Priority.Int64, 10),
-			i.Context.String,
-			i.Description.String,
-			i.Action.String,
-			i.Comment.String,
-			i.Created.Format(time.RFC3339),
-			i.Updated.Format(time.RFC3339),
+	defer out.abort()
+	err = fetchPages(ctx, func(ctx context.Context, mods ...qm.QueryMod) ([]*models.Event, error) {
+		return models.Events(mods...).All(ctx, conn)
+	}, mods, func(rows []*models.Event) error {
+		records := make([][]string, len(rows))
+		if exportResolve {
+			if err := contacts.load(ctx, conn, collectIDs(rows, func(e *models.Event) int64 { return e.Contact })); err != nil {
+				return err
+			}
 		}
-		if err := w.Write(record); err != nil {
-			return err
+		for i, e := range rows {
+			record := eventRecord(e)
+			if exportResolve {
+				record = append(record, contacts.name(e.Contact))
+			}
+			records[i] = record
 		}
+		return out.write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
 	}
 
-	fmt.Println("exported events.csv")
-	return nil
+	return out.finish()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-func exportTasks(ctx context.Context, conn *sql.DB) error {
-	rows, err := models.Tasks(qm.OrderBy("id ASC")).All(ctx, conn)
+func exportTasks(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) error {
+	total, err := countRows(ctx, conn, models.Tasks(mods...).Query)
 	if err != nil {
-		return fmt.Errorf("query tasks: %w", err)
+		return fmt.Errorf("count tasks: %w", err)
 	}
-
-	file, err := os.Create("tasks.csv")
+	out, err := newTableExport("tasks", taskColumns, total)
+	if err != nil {
+		return err
+	}
+	defer out.abort()
+	err = fetchPages(ctx, func(ctx context.Context, mods ...qm.QueryMod) ([]*models.Task, error) {
+		return models.Tasks(mods...).All(ctx, conn)
+	}, mods, func(rows []*models.Task) error {
+		records := make([][]string, len(rows))
+		for i, t := range rows {
+			records[i] = taskRecord(t)
+		}
+		return out.write(records)
+	})
 	if err != nil {
-		return fmt.Errorf("create tasks.csv: %w", err)
+		return fmt.Errorf("query tasks: %w", err)
 	}
-	defer file.Close()
 
-	w := csv.NewWriter(file)
-	defer w.Flush()
+	return out.finish()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
 
-	if err := w.Write([]string{"id", "interaction", "assigned", "title", "duedate", "status", "notes", "created", "updated"}); err != nil {
-		return err
+// joinedColumns lists the columns of export joined, prefixed by source table
+var joinedColumns = []string{
+	"task_id", "task_title", "task_duedate", "task_status", "task_notes",
+	"event_id", "event_occurred", "event_mode", "event_description",
+	"contact_id", "contact_name", "contact_email",
+	"org_id", "org_name", "org_location",
+}
+
+// joinedRow is one row of export joined; every joined side may be missing
+type joinedRow struct {
+	TaskID           int64       `boil:"task_id"`
+	TaskTitle        string      `boil:"task_title"`
+	TaskDuedate      null.Time   `boil:"task_duedate"`
+	TaskStatus       null.String `boil:"task_status"`
+	TaskNotes        null.String `boil:"task_notes"`
+	EventID          null.Int64  `boil:"event_id"`
+	EventOccurred    null.Time   `boil:"event_occurred"`
+	EventMode        null.String `boil:"event_mode"`
+	EventDescription null.String `boil:"event_description"`
+	ContactID        null.Int64  `boil:"contact_id"`
+	ContactName      null.String `boil:"contact_name"`
+	ContactEmail     null.String `boil:"contact_email"`
+	OrgID            null.Int64  `boil:"org_id"`
+	OrgName          null.String `boil:"org_name"`
+	OrgLocation      null.String `boil:"org_location"`
+}
+
+func exportJoined(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) error {
+	base := []qm.QueryMod{
+		qm.Select(
+			"tasks.id AS task_id", "tasks.title AS task_title", "tasks.duedate AS task_duedate",
+			"tasks.status AS task_status", "tasks.notes AS task_notes",
+			"events.id AS event_id", "events.occurred AS event_occurred",
+			"events.mode AS event_mode", "events.description AS event_description",
+			"contacts.id AS contact_id", "contacts.name AS contact_name", "contacts.email AS contact_email",
+			"orgs.id AS org_id", "orgs.name AS org_name", "orgs.location AS org_location",
+		),
+		qm.From("tasks"),
+		qm.LeftOuterJoin("events ON events.id = tasks.interaction"),
+		qm.LeftOuterJoin("contacts ON contacts.id = events.contact"),
+		qm.LeftOuterJoin("orgs ON orgs.id = contacts.org"),
 	}
 
-	for _, t := range rows {
-		record := []string{
-			strconv.FormatInt(t.ID.Int64, 10),
-			strconv.FormatInt(t.Interaction.Int64, 10),
-			strconv.FormatInt(t.Assigned.Int64, 10),
-			t.Title,
-			t.Duedate.Time.Format("2006-01-02"),
-			t.Status.String,
-			t.Notes.String,
-			t.Created.Format(time.RFC3339),
-			t.Updated.Format(time.RFC3339),
-		}
-		if err := w.Write(record); err != nil {
-			return err
+	total, err := countRows(ctx, conn, models.NewQuery(slices.Concat(base, mods)...))
+	if err != nil {
+		return fmt.Errorf("count joined: %w", err)
+	}
+	out, err := newTableExport("joined", joinedColumns, total)
+	if err != nil {
+		return err
+	}
+	defer out.abort()
+	err = fetchPages(ctx, func(ctx context.Context, mods ...qm.QueryMod) ([]joinedRow, error) {
+		var rows []joinedRow
+		err := models.NewQuery(slices.Concat(base, mods)...).Bind(ctx, conn, &rows)
+		return rows, err
+	}, mods, func(rows []joinedRow) error {
+		records := make([][]string, len(rows))
+		for i, r := range rows {
+			records[i] = []string{
+				strconv.FormatInt(r.TaskID, 10),
+				r.TaskTitle,
+				formatNullTime(r.TaskDuedate, "2006-01-02"),
+				r.TaskStatus.String,
+				r.TaskNotes.String,
+				formatNullID(r.EventID),
+				formatNullTime(r.EventOccurred, time.RFC3339),
+				r.EventMode.String,
+				r.EventDescription.String,
+				formatNullID(r.ContactID),
+				r.ContactName.String,
+				r.ContactEmail.String,
+				formatNullID(r.OrgID),
+				r.OrgName.String,
+				r.OrgLocation.String,
+			}
 		}
+		return out.write(records)
+	})
+	if err != nil {
+		return fmt.Errorf("query joined: %w", err)
 	}
 
-	fmt.Println("exported tasks.csv")
-	return nil
+	return out.finish()
+}
+
+// formatNullTime renders a missing time as an empty cell
+func formatNullTime(t null.Time, layout string) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(layout)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,337 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+	"github.com/DanielRivasMD/Zenith/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var importQuiet bool
+
+var importCmd = &cobra.Command{
+	Use:   "import [table] [file]",
+	Short: "Import a CSV file written by export",
+	Long: `Read a CSV file in the layout written by "zenith export" back into one
+table: orgs, contacts, events or tasks. Columns are matched by header name,
+so they may come in any order and be a subset; columns the table does not
+have (e.g. --resolve or --compute extras) are skipped with a warning.
+Events may name their contact by email instead of id: a contact_email
+column is matched case-insensitively against contacts and must find exactly
+one, taking precedence over a contact column in the same row.
+
+Rows whose id already exists are updated, only in the columns present; rows
+with a new id are inserted under that id, and rows with a blank or missing
+id get a fresh one. Blank optional cells become NULL; blank created/updated
+cells keep the stored time (or the default for new rows).
+
+All rows are written in one transaction: any bad row aborts the whole
+import, reporting its line. Files ending in .gz are decompressed.`,
+	Example: `  zenith import contacts contacts.csv
+  zenith import orgs backups/organizations.csv.gz
+  zenith import events calendar.csv`,
+	Args:              cobra.ExactArgs(2),
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	SilenceUsage:      true,
+	RunE:              runImport,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVarP(&importQuiet, "quiet", "q", false, "Suppress the progress line")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// importer binds one table's models to the shared CSV import loop
+type importer[T any] struct {
+	columns []string
+	find    func(ctx context.Context, exec boil.ContextExecutor, id int64) (row T, found bool, err error)
+	blank   func(id null.Int64) T
+	set     func(row T, col, val string) error
+	save    func(ctx context.Context, exec boil.ContextExecutor, row T, exists bool) error
+	lookups map[string]lookup // extra header names resolved into a real column
+}
+
+// lookup maps a cell of a non-table column (e.g. contact_email) to a value
+// for column, set after the table's own columns
+type lookup struct {
+	column  string
+	resolve func(ctx context.Context, exec boil.ContextExecutor, val string) (string, error)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runImport(cmd *cobra.Command, args []string) error {
+	table, name := args[0], args[1]
+
+	header, rows, err := readImportCSV(name)
+	if err != nil {
+		return err
+	}
+
+	var inserted, updated int
+	ctx := context.Background()
+	bar := newProgress(name, "imported", len(rows), importQuiet)
+	err = db.WithTx(ctx, func(tx boil.ContextExecutor) error {
+		switch table {
+		case "orgs", "organizations":
+			inserted, updated, err = importRows(ctx, tx, orgImporter, header, rows, bar)
+		case "contacts":
+			inserted, updated, err = importRows(ctx, tx, contactImporter, header, rows, bar)
+		case "events":
+			inserted, updated, err = importRows(ctx, tx, eventImporter, header, rows, bar)
+		case "tasks":
+			inserted, updated, err = importRows(ctx, tx, taskImporter, header, rows, bar)
+		default:
+			err = fmt.Errorf("unknown table %q (valid: orgs, contacts, events, tasks)", table)
+		}
+		return err
+	})
+	bar.finish()
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	fmt.Printf("imported %s: %d inserted, %d updated\n", name, inserted, updated)
+	return nil
+}
+
+// readImportCSV reads a whole CSV file, dropping a UTF-8 BOM (export --bom)
+// and gunzipping .gz files (export --gzip)
+func readImportCSV(name string) (header []string, rows [][]string, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%s: no header row", name)
+	}
+	header = records[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	return header, records[1:], nil
+}
+
+// importRows upserts every row through imp, matching cells to columns by header
+func importRows[T any](ctx context.Context, tx boil.ContextExecutor, imp importer[T], header []string, rows [][]string, bar *progress) (inserted, updated int, err error) {
+	var skipped []string
+	for _, col := range header {
+		if _, ok := imp.lookups[col]; !ok && !slices.Contains(imp.columns, col) {
+			skipped = append(skipped, col)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "import: skipping columns not in the table: %s\n", strings.Join(skipped, ", "))
+	}
+	idCol := slices.Index(header, "id")
+
+	for n, cells := range rows {
+		line := n + 2 // 1-based, after the header
+
+		var id null.Int64
+		if idCol >= 0 && cells[idCol] != "" {
+			v, err := strconv.ParseInt(cells[idCol], 10, 64)
+			if err != nil {
+				return inserted, updated, fmt.Errorf("line %d: invalid id %q", line, cells[idCol])
+			}
+			id = null.Int64From(v)
+		}
+
+		var row T
+		exists := false
+		if id.Valid {
+			if row, exists, err = imp.find(ctx, tx, id.Int64); err != nil {
+				return inserted, updated, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if !exists {
+			row = imp.blank(id)
+		}
+
+		for i, col := range header {
+			if _, ok := imp.lookups[col]; ok || col == "id" || slices.Contains(skipped, col) {
+				continue
+			}
+			if err := imp.set(row, col, cells[i]); err != nil {
+				return inserted, updated, fmt.Errorf("line %d: column %s: %w", line, col, err)
+			}
+		}
+		for i, col := range header {
+			lk, ok := imp.lookups[col]
+			if !ok || strings.TrimSpace(cells[i]) == "" {
+				continue
+			}
+			val, err := lk.resolve(ctx, tx, cells[i])
+			if err == nil {
+				err = imp.set(row, lk.column, val)
+			}
+			if err != nil {
+				return inserted, updated, fmt.Errorf("line %d: column %s: %w", line, col, err)
+			}
+		}
+
+		if err := imp.save(ctx, tx, row, exists); err != nil {
+			return inserted, updated, fmt.Errorf("line %d: %w", line, err)
+		}
+		if exists {
+			updated++
+		} else {
+			inserted++
+		}
+		bar.update(n + 1)
+	}
+	return inserted, updated, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var orgImporter = importer[*models.Org]{
+	columns: orgColumns,
+	find: func(ctx context.Context, exec boil.ContextExecutor, id int64) (*models.Org, bool, error) {
+		return foundRow(models.FindOrg(ctx, exec, null.Int64From(id)))
+	},
+	blank: func(id null.Int64) *models.Org { return &models.Org{ID: id} },
+	set:   setOrgColumn,
+	save: func(ctx context.Context, exec boil.ContextExecutor, o *models.Org, exists bool) error {
+		if exists {
+			_, err := o.Update(ctx, exec, boil.Infer())
+			return err
+		}
+		return o.Insert(ctx, exec, boil.Infer())
+	},
+}
+
+var contactImporter = importer[*models.Contact]{
+	columns: contactColumns,
+	find: func(ctx context.Context, exec boil.ContextExecutor, id int64) (*models.Contact, bool, error) {
+		return foundRow(models.FindContact(ctx, exec, null.Int64From(id)))
+	},
+	blank: func(id null.Int64) *models.Contact { return &models.Contact{ID: id} },
+	set:   setContactColumn,
+	save: func(ctx context.Context, exec boil.ContextExecutor, c *models.Contact, exists bool) error {
+		if exists {
+			_, err := c.Update(ctx, exec, boil.Infer())
+			return err
+		}
+		return c.Insert(ctx, exec, boil.Infer())
+	},
+}
+
+var eventImporter = importer[*models.Event]{
+	columns: eventColumns,
+	find: func(ctx context.Context, exec boil.ContextExecutor, id int64) (*models.Event, bool, error) {
+		return foundRow(models.FindEvent(ctx, exec, null.Int64From(id)))
+	},
+	blank: func(id null.Int64) *models.Event { return &models.Event{ID: id} },
+	set:   setEventColumn,
+	save: func(ctx context.Context, exec boil.ContextExecutor, e *models.Event, exists bool) error {
+		if exists {
+			_, err := e.Update(ctx, exec, boil.Infer())
+			return err
+		}
+		return e.Insert(ctx, exec, boil.Infer())
+	},
+	lookups: map[string]lookup{
+		"contact_email": {column: "contact", resolve: contactIDByEmail},
+	},
+}
+
+var taskImporter = importer[*models.Task]{
+	columns: taskColumns,
+	find: func(ctx context.Context, exec boil.ContextExecutor, id int64) (*models.Task, bool, error) {
+		return foundRow(models.FindTask(ctx, exec, null.Int64From(id)))
+	},
+	blank: func(id null.Int64) *models.Task { return &models.Task{ID: id} },
+	set:   setTaskColumn,
+	save: func(ctx context.Context, exec boil.ContextExecutor, t *models.Task, exists bool) error {
+		if exists {
+			_, err := t.Update(ctx, exec, boil.Infer())
+			return err
+		}
+		return t.Insert(ctx, exec, boil.Infer())
+	},
+}
+
+// contactIDByEmail finds the one contact whose email matches, ignoring case
+// and surrounding spaces as contact dupes do
+func contactIDByEmail(ctx context.Context, exec boil.ContextExecutor, email string) (string, error) {
+	contacts, err := models.Contacts(
+		qm.Select("id"),
+		qm.Where("LOWER(TRIM(email)) = LOWER(TRIM(?))", email),
+	).All(ctx, exec)
+	if err != nil {
+		return "", err
+	}
+	switch len(contacts) {
+	case 0:
+		return "", fmt.Errorf("no contact with email %s", strings.TrimSpace(email))
+	case 1:
+		return strconv.FormatInt(contacts[0].ID.Int64, 10), nil
+	default:
+		return "", fmt.Errorf("%d contacts share email %s", len(contacts), strings.TrimSpace(email))
+	}
+}
+
+// foundRow turns a Find result into (row, found), treating no row as not found
+func foundRow[T any](row T, err error) (T, bool, error) {
+	row, err = db.Found(row, err)
+	if errors.Is(err, db.ErrNotFound) {
+		return row, false, nil
+	}
+	return row, err == nil, err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -19,8 +19,11 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -38,27 +41,51 @@ var migrateCmd = &cobra.Command{
 	Run: runMigrate,
 }
 
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back the last N applied migrations (default 1)",
+	Args:  cobra.MaximumNArgs(1),
+
+	Run: runMigrateDown,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print the applied schema version and whether it is dirty",
+	Args:  cobra.NoArgs,
+
+	Run: runMigrateStatus,
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-var ()
+var (
+	migrateDBPath string // overrides --db for one-off migrations (e.g. backups)
+)
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
 	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateDownCmd, migrateStatusCmd)
+	migrateCmd.PersistentFlags().StringVar(&migrateDBPath, "db-path", "", "Migrate this database file instead of the default one")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runMigrate(cmd *cobra.Command, args []string) {
+	path, conn := openMigrateDB()
 
-	// InitDB creates the file and runs migrations
-	conn, err := db.InitDB(dbPath)
+	from, to, err := db.Migrate(conn)
 	if err != nil {
 		log.Fatalf("migrate failed: %v", err)
 	}
 
-	fmt.Printf("migrations applied; database at %s\n", dbPath)
+	if from == to {
+		fmt.Printf("already up to date (version %d); database at %s\n", to, path)
+	} else {
+		fmt.Printf("applied migrations: %d → %d; database at %s\n", from, to, path)
+	}
 
 	if conn != nil {
 		if err := conn.Close(); err != nil {
@@ -69,3 +96,63 @@ func runMigrate(cmd *cobra.Command, args []string) {
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runMigrateDown(cmd *cobra.Command, args []string) {
+	steps := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			log.Fatalf("invalid step count %q, expected a positive integer", args[0])
+		}
+		steps = n
+	}
+
+	path, conn := openMigrateDB()
+	defer conn.Close()
+
+	from, to, err := db.Rollback(conn, steps)
+	if err != nil {
+		log.Fatalf("migrate down failed: %v", err)
+	}
+	fmt.Printf("rolled back migrations: %d → %d; database at %s\n", from, to, path)
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) {
+	// opening would create a missing file, which is not what status should do
+	if _, err := os.Stat(migrateTarget()); err != nil {
+		log.Fatalf("migrate status failed: %v", err)
+	}
+	path, conn := openMigrateDB()
+	defer conn.Close()
+
+	version, dirty, pending, err := db.Status(conn)
+	if err != nil {
+		log.Fatalf("migrate status failed: %v", err)
+	}
+	state := "clean"
+	if dirty {
+		// golang-migrate refuses to run until the version is forced past the failure
+		state = "dirty, a migration failed midway"
+	}
+	fmt.Printf("version %d (%s), %d pending; database at %s\n", version, state, pending, path)
+}
+
+// migrateTarget is the database file named by --db-path, or --db by default
+func migrateTarget() string {
+	if migrateDBPath != "" {
+		return migrateDBPath
+	}
+	return dbPath
+}
+
+// openMigrateDB opens the migrateTarget database
+func openMigrateDB() (string, *sql.DB) {
+	path := migrateTarget()
+	conn, err := db.Open(path)
+	if err != nil {
+		log.Fatalf("migrate failed: %v", err)
+	}
+	return path, conn
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
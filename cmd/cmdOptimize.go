@@ -0,0 +1,75 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var optimizeReindex bool
+
+var optimizeCmd = &cobra.Command{
+	Use:   "optimize",
+	Short: "Refresh query planner statistics after bulk changes",
+	Long: `Run ANALYZE and PRAGMA optimize so SQLite plans queries from current
+statistics, which can go stale after large imports or deletes. --reindex
+first rebuilds every index. Safe to run at any time; data is untouched.`,
+	Example: `  zenith optimize
+  zenith optimize --reindex`,
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runOptimize,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(optimizeCmd)
+	optimizeCmd.Flags().BoolVar(&optimizeReindex, "reindex", false, "Rebuild all indexes before analyzing")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runOptimize(cmd *cobra.Command, args []string) {
+	steps := []string{"ANALYZE", "PRAGMA optimize"}
+	if optimizeReindex {
+		steps = append([]string{"REINDEX"}, steps...)
+	}
+
+	ctx := context.Background()
+	for _, stmt := range steps {
+		start := time.Now()
+		if _, err := db.Conn.ExecContext(ctx, stmt); err != nil {
+			log.Fatalf("%s: %v", stmt, err)
+		}
+		fmt.Printf("%-16s done in %s\n", stmt, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
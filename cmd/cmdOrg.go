@@ -21,15 +21,16 @@ package cmd
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
 	"strconv"
-	"strings"
 
 	"github.com/aarondl/null/v8"
 	"github.com/aarondl/sqlboiler/v4/boil"
 	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 
 	"github.com/DanielRivasMD/Zenith/db"
@@ -39,10 +40,9 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 var orgCmd = &cobra.Command{
-	Use:     "org",
-	Short:   "Manage orgs",
-	Long:    helpOrg,
-	Example: exampleOrg,
+	Use:   "org",
+	Short: "Manage orgs",
+	Long:  helpOrg,
 
 	PersistentPreRun:  persistentPreRun,
 	PersistentPostRun: persistentPostRun,
@@ -61,72 +61,117 @@ var orgEditCmd = &cobra.Command{
 	Run:   runOrgEdit,
 }
 
+var (
+	orgLikeID         int64 // --like: seed the add form from an existing org
+	orgAllowDuplicate bool  // --allow-duplicate-name: skip the case-insensitive name check
+)
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// orgColumns lists the orgs table columns in schema order
+var orgColumns = []string{"id", "name", "location", "allow_duplicate_name", "created", "updated"}
+
 func init() {
 	rootCmd.AddCommand(orgCmd)
 
 	RegisterCrudSubcommands(orgCmd, "", CrudModel[*models.Org]{
-		Singular: "org",
-		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Org, error) {
-			return models.Orgs(qm.OrderBy("id ASC")).All(ctx, conn)
+		Singular:   "org",
+		Columns:    orgColumns,
+		DateColumn: "created",
+		ListFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) ([]*models.Org, error) {
+			return models.Orgs(mods...).All(ctx, conn)
+		},
+		CountFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) (int64, error) {
+			return models.Orgs(mods...).Count(ctx, conn)
 		},
 		Format: func(o *models.Org) (int64, string) {
 			return o.ID.Int64, fmt.Sprintf("%s (%s)", o.Name, o.Location.String)
 		},
-		RemoveFn: func(ctx context.Context, conn *sql.DB, id int64) error {
-			org, err := models.FindOrg(ctx, conn, null.Int64From(id))
+		RemoveFn: func(ctx context.Context, exec boil.ContextExecutor, id int64) (int64, error) {
+			return models.Orgs(qm.Where("id = ?", id)).DeleteAll(ctx, exec)
+		},
+		Dependents: func(ctx context.Context, exec boil.ContextExecutor, id int64) ([]string, error) {
+			contacts, err := models.Contacts(qm.Where("org = ?", id)).Count(ctx, exec)
+			if err != nil {
+				return nil, err
+			}
+			events, err := models.Events(
+				qm.InnerJoin("contacts c ON c.id = events.contact"),
+				qm.Where("c.org = ?", id),
+			).Count(ctx, exec)
+			if err != nil {
+				return nil, err
+			}
+			// attendances of the org's contacts, or at the events they are primary on
+			attended, err := models.EventContacts(
+				qm.Where("contact IN (SELECT id FROM contacts WHERE org = ?)", id),
+				qm.Or("event IN (SELECT e.id FROM events e JOIN contacts c ON c.id = e.contact WHERE c.org = ?)", id),
+			).Count(ctx, exec)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			_, err = org.Delete(ctx, conn)
-			return err
+			// tasks survive the delete but lose their event (ON DELETE SET NULL)
+			linked, err := models.Tasks(
+				qm.InnerJoin("events e ON e.id = tasks.interaction"),
+				qm.InnerJoin("contacts c ON c.id = e.contact"),
+				qm.Where("c.org = ?", id),
+			).Count(ctx, exec)
+			if err != nil {
+				return nil, err
+			}
+			var deps []string
+			if contacts > 0 {
+				deps = append(deps, countLabel(int(contacts), "contact"))
+			}
+			if events > 0 {
+				deps = append(deps, countLabel(int(events), "event"))
+			}
+			if attended > 0 {
+				deps = append(deps, countLabel(int(attended), "attendance"))
+			}
+			if linked > 0 {
+				deps = append(deps, countLabel(int(linked), "task link"))
+			}
+			return deps, nil
+		},
+		ShowFn: func(ctx context.Context, conn *sql.DB, id int64) (string, error) {
+			o, err := db.Found(models.FindOrg(ctx, conn, null.Int64From(id)))
+			if err != nil {
+				return "", err
+			}
+			return formatRecord(orgColumns, orgRecord(o)), nil
 		},
 	})
 
 	// Add the interactive add/edit commands
 	orgCmd.AddCommand(orgAddCmd, orgEditCmd)
+	orgAddCmd.Flags().BoolVar(&orgAllowDuplicate, "allow-duplicate-name", false, "Allow a name that differs from an existing org only by case")
+	orgAddCmd.Flags().Int64Var(&orgLikeID, "like", 0, "Prefill the form from an existing org ID")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runOrgAdd(cmd *cobra.Command, args []string) {
 	org := &models.Org{}
+	if orgLikeID != 0 {
+		org = copyRecord("org", orgLikeID, models.FindOrg)
+	}
 
-	fields := []Field{
-		{
-			Label:   "Org Name",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("name cannot be blank")
-				}
-				return s, nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().FieldByName("Name").
-					SetString(v.(string))
-			},
-		},
-		{
-			Label:   "Location (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().FieldByName("Location").
-					Set(reflect.ValueOf(v))
-			},
-		},
+	org.AllowDuplicateName = 0
+	if orgAllowDuplicate {
+		org.AllowDuplicateName = 1
 	}
 
 	// Launch the Bubble Tea form wizard
-	RunFormWizard(fields, org)
+	if !RunFormWizard("org-add", orgFields(org), org) {
+		return
+	}
 
 	// Persist new org
-	if err := org.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("insert org: %v", err)
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		return org.Insert(context.Background(), exec, boil.Infer())
+	}); err != nil {
+		fatalOrgWrite("insert", org, err)
 	}
 	fmt.Printf("Created org %d\n", org.ID.Int64)
 }
@@ -141,21 +186,82 @@ func runOrgEdit(cmd *cobra.Command, args []string) {
 	}
 
 	// Load existing record
-	org, err := models.FindOrg(context.Background(), db.Conn, null.Int64From(idNum))
+	org, err := db.Found(models.FindOrg(context.Background(), db.Conn, null.Int64From(idNum)))
 	if err != nil {
-		log.Fatalf("find org: %v", err)
+		fatalFind("org", idNum, err)
+	}
+
+	if !RunFormWizard(fmt.Sprintf("org-edit-%d", idNum), orgFields(org), org) {
+		return
 	}
 
-	fields := []Field{
+	// Persist updates
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		_, err := org.Update(context.Background(), exec, boil.Infer())
+		return err
+	}); err != nil {
+		fatalOrgWrite("update", org, err)
+	}
+	fmt.Printf("Updated org %d\n", org.ID.Int64)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// fatalOrgWrite exits with a friendly message when a unique name index rejects the org.
+// An org already flagged allow_duplicate_name can only have hit the exact,
+// case-sensitive name constraint, which the flag does not lift
+func fatalOrgWrite(verb string, org *models.Org, err error) {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		switch {
+		case org.AllowDuplicateName != 0:
+			log.Fatalf("an organization named exactly %q already exists (--allow-duplicate-name only allows names differing by case)", org.Name)
+		case verb == "insert":
+			log.Fatalf("an organization named %q already exists (use --allow-duplicate-name to add it anyway)", org.Name)
+		default:
+			log.Fatalf("an organization named %q already exists", org.Name)
+		}
+	}
+	log.Fatalf("%s org: %v", verb, err)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// orgFields builds the wizard fields shared by add & edit, seeded from org
+func orgFields(org *models.Org) []Field {
+	return []Field{
 		{
-			Label:   "Org Name",
-			Initial: org.Name,
+			Label:    "Org Name",
+			Initial:  org.Name,
+			Required: true,
 			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("name cannot be blank")
-				}
 				return s, nil
 			},
+			Validate: func(holder any, raw string) error {
+				// exclude the org itself so edit can keep its name
+				org := holder.(*models.Org)
+				// mirrors the orgs_name_nocase index: Acme & acme collide,
+				// unless the org opted out, which still leaves the exact UNIQUE on name
+				mods := []qm.QueryMod{
+					qm.Where("name = ? COLLATE NOCASE", raw),
+					qm.Where("allow_duplicate_name = 0"),
+					qm.Where("id IS NOT ?", org.ID),
+				}
+				if org.AllowDuplicateName != 0 {
+					mods = []qm.QueryMod{qm.Where("name = ?", raw), qm.Where("id IS NOT ?", org.ID)}
+				}
+				taken, err := models.Orgs(mods...).Exists(context.Background(), db.Conn)
+				if err != nil {
+					return fmt.Errorf("check name: %w", err)
+				}
+				if taken && org.AllowDuplicateName != 0 {
+					return fmt.Errorf("an organization named exactly %q already exists", raw)
+				}
+				if taken {
+					return fmt.Errorf("an organization named %q already exists", raw)
+				}
+				return nil
+			},
 			Assign: func(holder any, v any) {
 				reflect.ValueOf(holder).Elem().FieldByName("Name").
 					SetString(v.(string))
@@ -173,14 +279,6 @@ func runOrgEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 	}
-
-	RunFormWizard(fields, org)
-
-	// Persist updates
-	if _, err := org.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("update org: %v", err)
-	}
-	fmt.Printf("Updated org %d\n", org.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
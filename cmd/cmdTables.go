@@ -0,0 +1,80 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+	"github.com/DanielRivasMD/Zenith/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var tablesCmd = &cobra.Command{
+	Use:               "tables",
+	Short:             "List the exportable tables with their row counts",
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runTables,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(tablesCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runTables(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	counts := []struct {
+		table string
+		count func() (int64, error)
+	}{
+		{"orgs", func() (int64, error) { return models.Orgs().Count(ctx, db.Conn) }},
+		{"contacts", func() (int64, error) { return models.Contacts().Count(ctx, db.Conn) }},
+		{"events", func() (int64, error) { return models.Events().Count(ctx, db.Conn) }},
+		{"tasks", func() (int64, error) { return models.Tasks().Count(ctx, db.Conn) }},
+	}
+
+	// names left-aligned, counts right-aligned
+	ns := make([]string, len(counts))
+	nameWidth, countWidth := 0, 0
+	for i, c := range counts {
+		n, err := c.count()
+		if err != nil {
+			log.Fatalf("count %s: %v", c.table, err)
+		}
+		ns[i] = fmt.Sprint(n)
+		nameWidth = max(nameWidth, len(c.table))
+		countWidth = max(countWidth, len(ns[i]))
+	}
+	for i, c := range counts {
+		fmt.Printf("%-*s  %*s\n", nameWidth, c.table, countWidth, ns[i])
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
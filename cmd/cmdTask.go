@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -44,7 +45,6 @@ var taskCmd = &cobra.Command{
 	Use:               "task",
 	Short:             "Manage tasks",
 	Long:              helpTask,
-	Example:           exampleTask,
 	PersistentPreRun:  persistentPreRun,
 	PersistentPostRun: persistentPostRun,
 }
@@ -62,134 +62,65 @@ var taskEditCmd = &cobra.Command{
 	Run:   runTaskEdit,
 }
 
+var taskLikeID int64 // --like: seed the add form from an existing task
+
+// taskStatuses are the values the task form accepts for status
+var taskStatuses = []string{"pending", "in-progress", "done", "cancelled"}
+
+// taskColumns lists the tasks table columns in schema order
+var taskColumns = []string{"id", "interaction", "assigned", "title", "duedate", "status", "notes", "created", "updated"}
+
 func init() {
 	rootCmd.AddCommand(taskCmd)
 
 	RegisterCrudSubcommands(taskCmd, "", CrudModel[*models.Task]{
-		Singular: "task",
-		ListFn: func(ctx context.Context, conn *sql.DB) ([]*models.Task, error) {
-			return models.Tasks(qm.OrderBy("id ASC")).All(ctx, conn)
+		Singular:     "task",
+		Columns:      taskColumns,
+		DateColumn:   "duedate",
+		DefaultOrder: "duedate ASC",
+		ListFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) ([]*models.Task, error) {
+			return models.Tasks(mods...).All(ctx, conn)
+		},
+		CountFn: func(ctx context.Context, conn *sql.DB, mods ...qm.QueryMod) (int64, error) {
+			return models.Tasks(mods...).Count(ctx, conn)
 		},
 		Format: func(t *models.Task) (int64, string) {
 			return t.ID.Int64, fmt.Sprintf("%s (status=%s)", t.Title, t.Status.String)
 		},
-		RemoveFn: func(ctx context.Context, conn *sql.DB, id int64) error {
-			tk, err := models.FindTask(ctx, conn, null.Int64From(id))
+		RemoveFn: func(ctx context.Context, exec boil.ContextExecutor, id int64) (int64, error) {
+			return models.Tasks(qm.Where("id = ?", id)).DeleteAll(ctx, exec)
+		},
+		ShowFn: func(ctx context.Context, conn *sql.DB, id int64) (string, error) {
+			t, err := db.Found(models.FindTask(ctx, conn, null.Int64From(id)))
 			if err != nil {
-				return err
+				return "", err
 			}
-			_, err = tk.Delete(ctx, conn)
-			return err
+			return formatRecord(taskColumns, taskRecord(t)), nil
 		},
 	})
 
 	taskCmd.AddCommand(taskAddCmd, taskEditCmd)
+	taskAddCmd.Flags().Int64Var(&taskLikeID, "like", 0, "Prefill the form from an existing task ID")
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func runTaskAdd(cmd *cobra.Command, args []string) {
-	tk := &models.Task{}
-
-	fields := []Field{
-		{
-			Label:   "Interaction ID (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return null.Int64{}, nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return null.Int64From(i), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Interaction").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Assigned (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return null.Int64{}, nil
-				}
-				i, err := strconv.ParseInt(s, 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				return null.Int64From(i), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Assigned").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Title",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("title cannot be blank")
-				}
-				return s, nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Title").
-					SetString(v.(string))
-			},
-		},
-		{
-			Label:   "Due Date (YYYY-MM-DD)",
-			Initial: time.Now().Format("2006-01-02"),
-			Parse: func(s string) (any, error) {
-				t, err := time.Parse("2006-01-02", s)
-				if err != nil {
-					return nil, err
-				}
-				return null.TimeFrom(t), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Duedate").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Status",
-			Initial: "pending",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Status").
-					Set(reflect.ValueOf(v))
-			},
-		},
-		{
-			Label:   "Notes (optional)",
-			Initial: "",
-			Parse: func(s string) (any, error) {
-				return null.StringFrom(s), nil
-			},
-			Assign: func(holder any, v any) {
-				reflect.ValueOf(holder).Elem().
-					FieldByName("Notes").
-					Set(reflect.ValueOf(v))
-			},
-		},
+	tk := &models.Task{
+		Duedate: null.TimeFrom(time.Now()),
+		Status:  null.StringFrom("pending"),
+	}
+	if taskLikeID != 0 {
+		tk = copyRecord("task", taskLikeID, models.FindTask)
 	}
 
-	RunFormWizard(fields, tk)
+	if !RunFormWizard("task-add", taskFields(tk), tk) {
+		return
+	}
 
-	if err := tk.Insert(context.Background(), db.Conn, boil.Infer()); err != nil {
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		return tk.Insert(context.Background(), exec, boil.Infer())
+	}); err != nil {
 		log.Fatalf("insert task: %v", err)
 	}
 	fmt.Printf("Created task %d\n", tk.ID.Int64)
@@ -203,15 +134,33 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 		log.Fatalf("invalid task ID %q: %v", args[0], err)
 	}
 
-	tk, err := models.FindTask(context.Background(), db.Conn, null.Int64From(idNum))
+	tk, err := db.Found(models.FindTask(context.Background(), db.Conn, null.Int64From(idNum)))
 	if err != nil {
-		log.Fatalf("find task: %v", err)
+		fatalFind("task", idNum, err)
 	}
 
-	fields := []Field{
+	if !RunFormWizard(fmt.Sprintf("task-edit-%d", idNum), taskFields(tk), tk) {
+		return
+	}
+
+	if err := db.WithTx(context.Background(), func(exec boil.ContextExecutor) error {
+		_, err := tk.Update(context.Background(), exec, boil.Infer())
+		return err
+	}); err != nil {
+		log.Fatalf("update task: %v", err)
+	}
+	fmt.Printf("Updated task %d\n", tk.ID.Int64)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// taskFields builds the wizard fields shared by add & edit, seeded from tk
+func taskFields(tk *models.Task) []Field {
+	return []Field{
 		{
 			Label:   "Interaction ID (optional)",
-			Initial: strconv.FormatInt(tk.Interaction.Int64, 10),
+			Initial: formatNullID(tk.Interaction),
+			Hint:    "event ID, see: zenith event list",
 			Parse: func(s string) (any, error) {
 				if strings.TrimSpace(s) == "" {
 					return null.Int64{}, nil
@@ -230,7 +179,7 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 		},
 		{
 			Label:   "Assigned (optional)",
-			Initial: strconv.FormatInt(tk.Assigned.Int64, 10),
+			Initial: formatNullID(tk.Assigned),
 			Parse: func(s string) (any, error) {
 				if strings.TrimSpace(s) == "" {
 					return null.Int64{}, nil
@@ -248,12 +197,10 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 		{
-			Label:   "Title",
-			Initial: tk.Title,
+			Label:    "Title",
+			Initial:  tk.Title,
+			Required: true,
 			Parse: func(s string) (any, error) {
-				if strings.TrimSpace(s) == "" {
-					return nil, fmt.Errorf("title cannot be blank")
-				}
 				return s, nil
 			},
 			Assign: func(holder any, v any) {
@@ -265,8 +212,9 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 		{
 			Label:   "Due Date (YYYY-MM-DD)",
 			Initial: tk.Duedate.Time.Format("2006-01-02"),
+			Hint:    "e.g. 2025-01-02, or epoch seconds",
 			Parse: func(s string) (any, error) {
-				t, err := time.Parse("2006-01-02", s)
+				t, err := parseTimeOrEpoch(s, "2006-01-02")
 				if err != nil {
 					return nil, err
 				}
@@ -281,7 +229,12 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 		{
 			Label:   "Status",
 			Initial: tk.Status.String,
+			Hint:    "allowed: " + strings.Join(taskStatuses, ", "),
 			Parse: func(s string) (any, error) {
+				s = strings.ToLower(strings.TrimSpace(s))
+				if !slices.Contains(taskStatuses, s) {
+					return nil, fmt.Errorf("status must be one of %s", strings.Join(taskStatuses, ", "))
+				}
 				return null.StringFrom(s), nil
 			},
 			Assign: func(holder any, v any) {
@@ -303,13 +256,6 @@ func runTaskEdit(cmd *cobra.Command, args []string) {
 			},
 		},
 	}
-
-	RunFormWizard(fields, tk)
-
-	if _, err := tk.Update(context.Background(), db.Conn, boil.Infer()); err != nil {
-		log.Fatalf("update task: %v", err)
-	}
-	fmt.Printf("Updated task %d\n", tk.ID.Int64)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
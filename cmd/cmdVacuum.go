@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DanielRivasMD/Zenith/db"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var vacuumCmd = &cobra.Command{
+	Use:   "vacuum",
+	Short: "Reclaim the space left behind by deleted rows",
+	Long: `Rebuild the database with VACUUM so pages freed by deletes are returned to
+the filesystem, then checkpoint the write-ahead log so the shrink reaches the
+main file instead of waiting in the -wal sidecar. Sizes before and after
+include the sidecar files. Other zenith processes wait while this runs.`,
+	Example: `  zenith vacuum
+  zenith vacuum --db work.db`,
+	Args:              cobra.NoArgs,
+	PersistentPreRun:  persistentPreRun,
+	PersistentPostRun: persistentPostRun,
+	Run:               runVacuum,
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	rootCmd.AddCommand(vacuumCmd)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func runVacuum(cmd *cobra.Command, args []string) {
+	before, err := diskSize(dbPath)
+	if err != nil {
+		log.Fatalf("vacuum: %v", err)
+	}
+
+	// in WAL mode VACUUM writes the rebuilt pages to the log, so the main file
+	// only shrinks once they are checkpointed and the log truncated
+	ctx := context.Background()
+	for _, stmt := range []string{"VACUUM", "PRAGMA wal_checkpoint(TRUNCATE)"} {
+		if _, err := db.Conn.ExecContext(ctx, stmt); err != nil {
+			log.Fatalf("vacuum: %s: %v", stmt, err)
+		}
+	}
+
+	after, err := diskSize(dbPath)
+	if err != nil {
+		log.Fatalf("vacuum: %v", err)
+	}
+	fmt.Printf("vacuumed %s: %s → %s bytes\n", dbPath, humanCount(int(before)), humanCount(int(after)))
+}
+
+// diskSize sums the size of a database file and whichever sidecars it has
+func diskSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	total := info.Size()
+	for _, suffix := range dbSidecars {
+		info, err := os.Stat(path + suffix)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -19,13 +19,16 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"errors"
 	"log"
+	"os"
+	"path/filepath"
 
-	"github.com/DanielRivasMD/horus"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/DanielRivasMD/Zenith/db"
 )
@@ -42,7 +45,13 @@ var rootCmd = &cobra.Command{
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func Execute() {
-	horus.CheckErr(rootCmd.Execute())
+	setEntityExamples()
+	err := rootCmd.Execute()
+	stopProfiling()
+	// cobra has already printed "Error: ..."; only the exit status is left
+	if err != nil {
+		os.Exit(1)
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -50,23 +59,99 @@ func Execute() {
 var (
 	verbose bool
 	dbPath  string // populated by the --db flag
+	dbURL   string // --db-url: sqlite:///path or postgres://..., replaces --db
+	locale  string // --locale: BCP 47 tag for human-facing dates & counts
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func init() {
+	cobra.OnInitialize(initConfig, startProfiling)
+
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose diagnostics")
 	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "zenith.db", "path to sqlite database")
+	rootCmd.PersistentFlags().StringVar(&locale, "locale", "", "locale for displayed dates & numbers, e.g. de-DE (exports unaffected)")
+	rootCmd.PersistentFlags().StringVar(&dbURL, "db-url", "", "database URL, e.g. sqlite:///path/to.db (replaces --db)")
+	rootCmd.PersistentFlags().StringVar(&db.MigrationsDir, "migrations", "", "read migration scripts from this directory instead of the built-in ones")
+
+	// profiling for contributors, kept out of --help
+	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "write a pprof CPU profile to file")
+	rootCmd.PersistentFlags().StringVar(&memProfile, "memprofile", "", "write a pprof heap profile to file")
+	rootCmd.PersistentFlags().MarkHidden("cpuprofile")
+	rootCmd.PersistentFlags().MarkHidden("memprofile")
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// initConfig reads ~/.zenith/config/config.toml when present
+// an explicit --db flag always wins over the configured db-path
+func initConfig() {
+	if home, err := os.UserHomeDir(); err == nil {
+		viper.AddConfigPath(filepath.Join(home, ".zenith", "config"))
+	}
+	viper.SetConfigName("config")
+	viper.SetConfigType("toml")
+	viper.SetDefault("confirm-threshold", 0)
+
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			log.Fatalf("read config: %v", err)
+		}
+	}
+
+	if !rootCmd.PersistentFlags().Changed("db") && viper.IsSet("db-path") {
+		dbPath = configPath("db-path")
+	}
+
+	if !rootCmd.PersistentFlags().Changed("locale") {
+		locale = viper.GetString("locale")
+	}
+	if err := setDisplayLocale(locale); err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	if dbURL != "" {
+		if rootCmd.PersistentFlags().Changed("db") {
+			log.Fatalf("--db and --db-url cannot be used together")
+		}
+		driver, dsn, err := db.ParseURL(dbURL)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		// sqlite keeps dbPath a plain file path for commands that touch the file
+		dbPath = dbURL
+		if driver == db.DriverSQLite {
+			dbPath = dsn
+		}
+	}
+}
+
+// configPath returns a path-like config value with $VAR / ${VAR} expanded
+// keys read through here: db-path
+func configPath(key string) string {
+	return os.ExpandEnv(viper.GetString(key))
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func persistentPreRun(cmd *cobra.Command, args []string) {
-	if _, err := db.InitDB(dbPath); err != nil {
+	if err := openDB(); err != nil {
 		log.Fatalf("init DB: %v", err)
 	}
 }
 
+// openDB connects db.Conn to the database selected by --db / config
+func openDB() error {
+	db.Retrying = func(attempt int, err error) {
+		if verbose {
+			log.Printf("database is locked, retrying (attempt %d): %v", attempt, err)
+		}
+	}
+	_, err := db.InitDB(dbPath)
+	return err
+}
+
 func persistentPostRun(cmd *cobra.Command, args []string) {
 	if db.Conn != nil {
 		_ = db.Conn.Close()
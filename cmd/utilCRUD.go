@@ -19,16 +19,30 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"database/sql"
+	"github.com/aarondl/null/v8"
+	"github.com/aarondl/sqlboiler/v4/boil"
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/ttacon/chalk"
 
 	"github.com/DanielRivasMD/Zenith/db"
 )
@@ -36,10 +50,24 @@ import (
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type CrudModel[T any] struct {
-	Singular string
-	ListFn   func(ctx context.Context, db *sql.DB) ([]T, error)
-	Format   func(item T) (int64, string)
-	RemoveFn func(ctx context.Context, db *sql.DB, id int64) error
+	Singular     string
+	Columns      []string // columns accepted by --where / --sort
+	DateColumn   string   // column filtered by --since / --until
+	DefaultOrder string   // list ORDER BY without --sort, e.g. "occurred DESC"; "" = "id ASC"
+	ListFn       func(ctx context.Context, db *sql.DB, mods ...qm.QueryMod) ([]T, error)
+	CountFn      func(ctx context.Context, db *sql.DB, mods ...qm.QueryMod) (int64, error) // COUNT(*) with the same mods
+	Format       func(item T) (int64, string)
+	RemoveFn     func(ctx context.Context, exec boil.ContextExecutor, id int64) (int64, error) // rows deleted
+
+	// ShowFn is optional: one record with every column labelled, for show
+	ShowFn func(ctx context.Context, db *sql.DB, id int64) (string, error)
+
+	// Dependents is optional: what still references id, e.g. "2 contacts".
+	// Foreign keys delete those rows along with id, so rm refuses unless --cascade
+	Dependents func(ctx context.Context, exec boil.ContextExecutor, id int64) ([]string, error)
+
+	// Age is optional: the date list --color-by-age shades each row by
+	Age func(item T) time.Time
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -53,77 +81,566 @@ func RegisterCrudSubcommands[T any](
 	parent.PersistentPostRun = persistentPostRun
 
 	// list
+	var (
+		quiet     bool
+		idsOnly   bool
+		countOnly bool
+		byAge     bool
+		asJSON    bool
+		offset    int
+		query     queryFlags
+	)
 	list := &cobra.Command{
 		Use:   "list",
 		Short: fmt.Sprintf("List all %s", desc.Singular),
 		Run: func(cmd *cobra.Command, args []string) {
+			if offset < 0 {
+				log.Fatalf("list %s: --offset must not be negative", desc.Singular)
+			}
+			mods, err := buildQueryMods(query, desc.Columns, desc.DateColumn, desc.DefaultOrder)
+			if err != nil {
+				log.Fatalf("list %s: %v", desc.Singular, err)
+			}
 			ctx := db.Ctx()
-			items, err := desc.ListFn(ctx, db.Conn)
+			paged := query.limit > 0 || offset > 0
+			if countOnly {
+				n, err := desc.CountFn(ctx, db.Conn, mods...)
+				if err != nil {
+					log.Fatalf("count %s: %v", desc.Singular, err)
+				}
+				fmt.Println(n)
+				return
+			}
+			shown := 0
+			shade := byAge && colorEnabled()
+			now := time.Now()
+			show := func(items []T) error {
+				for i, it := range items {
+					if asJSON {
+						raw, err := json.MarshalIndent(it, "  ", "  ")
+						if err != nil {
+							return err
+						}
+						sep := ","
+						if shown+i == 0 {
+							sep = "["
+						}
+						fmt.Printf("%s\n  %s", sep, raw)
+						continue
+					}
+					id, human := desc.Format(it)
+					if idsOnly {
+						fmt.Println(id)
+						continue
+					}
+					if shade {
+						human = colorByAge(human, desc.Age(it), now)
+					}
+					fmt.Printf("%d\t%s\n", id, human)
+				}
+				shown += len(items)
+				return nil
+			}
+			// the footer total counts every match, so it is taken before the offset
+			var total int64
+			if paged && !quiet && !idsOnly {
+				if total, err = desc.CountFn(ctx, db.Conn, mods...); err != nil {
+					log.Fatalf("count %s: %v", desc.Singular, err)
+				}
+			}
+			// without --limit, rows are streamed page by page
+			if query.limit > 0 {
+				var items []T
+				if items, err = desc.ListFn(ctx, db.Conn, append(mods, qm.Offset(offset))...); err == nil {
+					err = show(items)
+				}
+			} else {
+				err = db.PaginateFrom(ctx, func(ctx context.Context, page ...qm.QueryMod) ([]T, error) {
+					return desc.ListFn(ctx, db.Conn, slices.Concat(mods, page)...)
+				}, offset, db.PageSize, show)
+			}
 			if err != nil {
 				log.Fatalf("list %s: %v", desc.Singular, err)
 			}
-			for _, it := range items {
-				id, human := desc.Format(it)
-				fmt.Printf("%d\t%s\n", id, human)
+			switch {
+			case asJSON && shown == 0:
+				fmt.Println("[]")
+			case asJSON:
+				fmt.Println("\n]")
+			case quiet || idsOnly:
+			case paged && shown == 0:
+				fmt.Printf("showing none of %s\n", countLabel(int(total), desc.Singular))
+			case paged:
+				fmt.Printf("showing %d-%d of %s\n", offset+1, offset+shown, countLabel(int(total), desc.Singular))
+			default:
+				fmt.Println(countLabel(shown, desc.Singular))
 			}
 		},
 	}
+	list.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress the trailing row count")
+	list.Flags().BoolVar(&idsOnly, "ids-only", false, "Print only primary keys, one per line")
+	list.Flags().BoolVar(&countOnly, "count-only", false, "Print only the number of matching rows")
+	list.Flags().IntVar(&offset, "offset", 0, "Skip this many rows before listing (with --limit, pages through results)")
+	list.Flags().BoolVar(&asJSON, "json", false, "Print matching rows as a JSON array instead of lines")
+	list.MarkFlagsMutuallyExclusive("ids-only", "count-only", "json")
+	if desc.Age != nil {
+		list.Flags().BoolVar(&byAge, "color-by-age", false, "Bold today's rows, dim those older than a week (terminal only)")
+		list.MarkFlagsMutuallyExclusive("color-by-age", "json")
+	}
+	registerQueryFlags(list, &query)
+	registerSortCompletion(list, desc.Columns)
 	parent.AddCommand(list)
 
+	// count
+	var countQuery queryFlags
+	count := &cobra.Command{
+		Use:   "count",
+		Short: fmt.Sprintf("Print the number of %ss", desc.Singular),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			mods, err := buildQueryMods(countQuery, desc.Columns, desc.DateColumn, desc.DefaultOrder)
+			if err != nil {
+				log.Fatalf("count %s: %v", desc.Singular, err)
+			}
+			n, err := desc.CountFn(db.Ctx(), db.Conn, mods...)
+			if err != nil {
+				log.Fatalf("count %s: %v", desc.Singular, err)
+			}
+			fmt.Println(n)
+		},
+	}
+	registerFilterFlags(count, &countQuery)
+	parent.AddCommand(count)
+
 	// rm
+	var dryRun, yes, cascade bool
 	rm := &cobra.Command{
-		Use:   "rm [id]",
-		Short: fmt.Sprintf("Remove a %s by ID", desc.Singular),
-		Args:  cobra.ExactArgs(1),
+		Use:   "rm [id|from-to...]",
+		Short: fmt.Sprintf("Remove one or more %ss by ID or range (interactive picker without IDs)", desc.Singular),
+		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			raw, err := strconv.ParseInt(args[0], 10, 64)
+			if len(args) == 0 {
+				removeInteractive(desc, dryRun, yes, cascade)
+				return
+			}
+
+			// parse everything up front so a typo aborts before any delete
+			ids, err := parseIDArgs(args)
 			if err != nil {
-				log.Fatalf("invalid id: %v", err)
+				log.Fatalf("rm %s: %v", desc.Singular, err)
 			}
 			ctx := db.Ctx()
-			if err := desc.RemoveFn(ctx, db.Conn, raw); err != nil {
+
+			lines, err := affectedLines(ctx, desc, ids)
+			if err != nil {
 				log.Fatalf("rm %s: %v", desc.Singular, err)
 			}
-			fmt.Printf("Removed %s %d\n", desc.Singular, raw)
-		},
+			checkDependents(ctx, desc, ids, cascade, dryRun)
+			if !previewPlan(dryRun, "remove", lines) {
+				return
+			}
+			if !confirmRemove(lines, desc.Singular, yes) {
+				fmt.Println("nothing removed")
+				return
+			}
 
-		// optional: live completion of IDs
-		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-			ctx := db.Ctx()
-			items, err := desc.ListFn(ctx, db.Conn)
-			if err != nil {
-				return nil, cobra.ShellCompDirectiveError
-			}
-			var comps []string
-			for _, it := range items {
-				id, _ := desc.Format(it)
-				s := strconv.FormatInt(id, 10)
-				if toComplete == "" || strings.HasPrefix(s, toComplete) {
-					comps = append(comps, s)
+			var removed []int64
+			err = db.WithTx(ctx, func(exec boil.ContextExecutor) error {
+				for _, id := range ids {
+					n, err := desc.RemoveFn(ctx, exec, id)
+					if err != nil {
+						return fmt.Errorf("%s %d: %w", desc.Singular, id, err)
+					}
+					if n == 0 {
+						fmt.Printf("no such %s %d\n", desc.Singular, id)
+						continue
+					}
+					removed = append(removed, id)
 				}
+				return nil
+			})
+			if err != nil {
+				log.Fatalf("rm %v", err)
+			}
+			for _, id := range removed {
+				fmt.Printf("Removed %s %d\n", desc.Singular, id)
 			}
-			return comps, cobra.ShellCompDirectiveNoFileComp
 		},
+
+		ValidArgsFunction: completeIDs(desc),
+	}
+	rm.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without deleting")
+	rm.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	if desc.Dependents != nil {
+		rm.Flags().BoolVar(&cascade, "cascade", false, fmt.Sprintf("Also remove the rows that reference the %s", desc.Singular))
 	}
 	parent.AddCommand(rm)
+
+	// show
+	if desc.ShowFn != nil {
+		show := &cobra.Command{
+			Use:   "show [id]",
+			Short: fmt.Sprintf("Print every field of one %s", desc.Singular),
+			Args:  cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				id, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					log.Fatalf("invalid %s ID %q: %v", desc.Singular, args[0], err)
+				}
+				out, err := desc.ShowFn(db.Ctx(), db.Conn, id)
+				if err != nil {
+					fatalFind(desc.Singular, id, err)
+				}
+				fmt.Print(out)
+			},
+			ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) > 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return completeIDs(desc)(cmd, args, toComplete)
+			},
+		}
+		parent.AddCommand(show)
+	}
+}
+
+// completeIDs offers live completion of IDs, labelled with the human summary
+func completeIDs[T any](desc CrudModel[T]) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// completion bypasses PersistentPreRun; read the file as it is, so
+		// pressing tab never creates or migrates a database
+		conn := db.Conn
+		if conn == nil {
+			ro, err := db.OpenReadOnly(dbPath)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			defer ro.Close()
+			conn = ro
+		}
+		ctx := db.Ctx()
+		items, err := desc.ListFn(ctx, conn, qm.OrderBy("id ASC"))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var comps []string
+		for _, it := range items {
+			id, human := desc.Format(it)
+			s := strconv.FormatInt(id, 10)
+			if toComplete == "" || strings.HasPrefix(s, toComplete) {
+				// cobra treats text after a tab as the completion description
+				comps = append(comps, s+"\t"+human)
+			}
+		}
+		return comps, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// removeInteractive lets the user tick records in a picker and deletes them in one transaction
+func removeInteractive[T any](desc CrudModel[T], dryRun, yes, cascade bool) {
+	ctx := db.Ctx()
+	items, err := desc.ListFn(ctx, db.Conn, qm.OrderBy("id ASC"))
+	if err != nil {
+		log.Fatalf("list %s: %v", desc.Singular, err)
+	}
+	if len(items) == 0 {
+		fmt.Printf("no %ss to remove\n", desc.Singular)
+		return
+	}
+
+	picks := make([]pickItem, len(items))
+	labels := make(map[int64]string, len(items))
+	for i, it := range items {
+		id, human := desc.Format(it)
+		picks[i] = pickItem{id: id, label: human}
+		labels[id] = human
+	}
+
+	ids, ok := runPicker(fmt.Sprintf("Select %ss to remove", desc.Singular), picks, true)
+	if !ok || len(ids) == 0 {
+		fmt.Println("nothing removed")
+		return
+	}
+
+	lines := make([]string, len(ids))
+	for i, id := range ids {
+		lines[i] = fmt.Sprintf("%s %d: %s", desc.Singular, id, labels[id])
+	}
+	checkDependents(ctx, desc, ids, cascade, dryRun)
+	if !previewPlan(dryRun, "remove", lines) {
+		return
+	}
+	if !confirmRemove(lines, desc.Singular, yes) {
+		fmt.Println("nothing removed")
+		return
+	}
+
+	var removed int64
+	err = db.WithTx(ctx, func(exec boil.ContextExecutor) error {
+		for _, id := range ids {
+			n, err := desc.RemoveFn(ctx, exec, id)
+			if err != nil {
+				return fmt.Errorf("%s %d: %w", desc.Singular, id, err)
+			}
+			removed += n
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("rm %v", err)
+	}
+	fmt.Printf("Removed %s\n", countLabel(int(removed), desc.Singular))
+}
+
+// checkDependents reports the rows still referencing ids. Without cascade it
+// exits before anything is deleted, since the schema's ON DELETE rules would
+// take those rows with them; under dry-run it only lists them with the plan
+func checkDependents[T any](ctx context.Context, desc CrudModel[T], ids []int64, cascade, dryRun bool) {
+	if desc.Dependents == nil {
+		return
+	}
+	blocked := false
+	for _, id := range ids {
+		deps, err := desc.Dependents(ctx, db.Conn, id)
+		if err != nil {
+			log.Fatalf("check %s %d: %v", desc.Singular, id, err)
+		}
+		if len(deps) == 0 {
+			continue
+		}
+		switch {
+		case dryRun && cascade:
+			fmt.Printf("would also remove %s with %s %d\n", strings.Join(deps, ", "), desc.Singular, id)
+			continue
+		case dryRun:
+			fmt.Printf("%s %d is still referenced by %s (a real delete needs --cascade)\n", desc.Singular, id, strings.Join(deps, ", "))
+			continue
+		case cascade:
+			fmt.Printf("%s %d also removes %s\n", desc.Singular, id, strings.Join(deps, ", "))
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s %d is still referenced by %s\n", desc.Singular, id, strings.Join(deps, ", "))
+		blocked = true
+	}
+	if blocked {
+		log.Fatalf("nothing removed; pass --cascade to remove the dependent rows too")
+	}
+}
+
+// affectedLines renders the records matching ids, one "singular id: summary" line each
+func affectedLines[T any](ctx context.Context, desc CrudModel[T], ids []int64) ([]string, error) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	items, err := desc.ListFn(ctx, db.Conn, qm.WhereIn("id IN ?", args...), qm.OrderBy("id ASC"))
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, len(items))
+	for i, it := range items {
+		id, human := desc.Format(it)
+		lines[i] = fmt.Sprintf("%s %d: %s", desc.Singular, id, human)
+	}
+	return lines, nil
+}
+
+// previewPlan is the shared dry-run step for destructive commands: under
+// --dry-run it prints every affected row and returns false so the caller
+// stops before touching the DB
+func previewPlan(dryRun bool, verb string, lines []string) bool {
+	if !dryRun {
+		return true
+	}
+	for _, l := range lines {
+		fmt.Printf("would %s %s\n", verb, l)
+	}
+	fmt.Printf("dry run: %d row(s) would be affected, nothing changed\n", len(lines))
+	return false
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N] ", prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// confirmRemove asks before deleting the rows in lines (as built by
+// affectedLines) when there are more than the confirm-threshold config key
+// (default 0, so every delete asks); yes skips the prompt. Without a terminal
+// to ask on, it refuses rather than guess
+func confirmRemove(lines []string, singular string, yes bool) bool {
+	if yes || len(lines) <= viper.GetInt("confirm-threshold") {
+		return true
+	}
+	if !stdinIsTerminal() {
+		log.Fatalf("refusing to remove %s without --yes: stdin is not a terminal", countLabel(len(lines), singular))
+	}
+	if len(lines) == 1 {
+		return confirm(fmt.Sprintf("delete %s?", lines[0]))
+	}
+	return confirm(fmt.Sprintf("delete %s?", countLabel(len(lines), singular)))
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// maxIDRange caps how many IDs a single from-to argument may expand to
+const maxIDRange = 1000
+
+// parseIDArgs expands rm arguments into IDs: plain "12" or inclusive "10-15",
+// dropping repeats while keeping first-seen order
+func parseIDArgs(args []string) ([]int64, error) {
+	var ids []int64
+	seen := map[int64]bool{}
+	add := func(id int64) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, a := range args {
+		from, to, isRange := strings.Cut(a, "-")
+		if !isRange {
+			id, err := strconv.ParseInt(a, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid id %q", a)
+			}
+			add(id)
+			continue
+		}
+
+		lo, errLo := strconv.ParseInt(from, 10, 64)
+		hi, errHi := strconv.ParseInt(to, 10, 64)
+		if errLo != nil || errHi != nil {
+			return nil, fmt.Errorf("invalid range %q, expected from-to", a)
+		}
+		if lo > hi {
+			return nil, fmt.Errorf("reversed range %q, did you mean %d-%d?", a, hi, lo)
+		}
+		if hi-lo >= maxIDRange {
+			return nil, fmt.Errorf("range %q spans %d ids, limit is %d", a, hi-lo+1, maxIDRange)
+		}
+		for id := lo; id <= hi; id++ {
+			add(id)
+		}
+	}
+	return ids, nil
+}
+
+// fatalFind exits with "<singular> <id> not found" for a missing row, or the lookup error otherwise
+func fatalFind(singular string, id int64, err error) {
+	if errors.Is(err, db.ErrNotFound) {
+		log.Fatalf("%s %d not found", singular, id)
+	}
+	log.Fatalf("find %s %d: %v", singular, id, err)
+}
+
+// findFn is the shape of the generated models.FindX lookups
+type findFn[T any] func(ctx context.Context, exec boil.ContextExecutor, id null.Int64, selectCols ...string) (T, error)
+
+// copyRecord loads row id for an add --like form: the values are kept, while
+// the ID and timestamps are cleared so Insert creates a new row
+func copyRecord[T any](singular string, id int64, find findFn[T]) T {
+	src, err := db.Found(find(context.Background(), db.Conn, null.Int64From(id)))
+	if err != nil {
+		fatalFind(singular, id, err)
+	}
+	v := reflect.ValueOf(src).Elem()
+	for _, name := range []string{"ID", "Created", "Updated"} {
+		f := v.FieldByName(name)
+		f.Set(reflect.Zero(f.Type()))
+	}
+	return src
+}
+
+// countLabel renders "1 event" / "3 events" for list summaries
+func countLabel(n int, singular string) string {
+	if n == 1 {
+		return fmt.Sprintf("%s %s", humanCount(n), singular)
+	}
+	return fmt.Sprintf("%s %ss", humanCount(n), singular)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Field struct {
-	Name    string                    // struct field name
-	Label   string                    // what to show user
-	Initial string                    // starting value input box
-	Parse   func(string) (any, error) // raw string → typed value
-	Assign  func(holder any, v any)   // setter write into model
-	Input   textinput.Model           // the Bubble Tea textinput component
+	Name     string                             // struct field name
+	Label    string                             // what to show user
+	Initial  string                             // starting value input box
+	Hint     string                             // constraint shown under the label
+	Help     string                             // optional longer guidance, dimmed under the input
+	Required bool                               // refuse to advance while the input is blank
+	Mask     bool                               // hide typed text, e.g. tokens; never saved in drafts
+	Parse    func(string) (any, error)          // raw string → typed value
+	Validate func(holder any, raw string) error // optional check on enter, e.g. uniqueness
+	Assign   func(holder any, v any)            // setter write into model
+	Input    textinput.Model                    // the Bubble Tea textinput component
+}
+
+// formatID renders a foreign key as a field's initial value, leaving unset (0) keys blank
+func formatID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// formatNullID is formatID for nullable keys
+func formatNullID(id null.Int64) string {
+	if !id.Valid {
+		return ""
+	}
+	return formatID(id.Int64)
+}
+
+// validateRef is a Field.Validate that rejects IDs with no row in the
+// referenced table, reported as "<singular> <id> not found"
+func validateRef(singular string, exists func(ctx context.Context, exec boil.ContextExecutor, id null.Int64) (bool, error)) func(holder any, raw string) error {
+	return func(holder any, raw string) error {
+		id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s ID %q", singular, raw)
+		}
+		ok, err := exists(context.Background(), db.Conn, null.Int64From(id))
+		if err != nil {
+			return fmt.Errorf("check %s %d: %w", singular, id, err)
+		}
+		if !ok {
+			return fmt.Errorf("%s %d not found", singular, id)
+		}
+		return nil
+	}
+}
+
+// minEpoch is the smallest all-digit input read as Unix seconds (1973-03-03);
+// shorter numbers could be compact YYYYMMDD dates and are left to the layout
+const minEpoch = 100_000_000
+
+// parseTimeOrEpoch parses s with layout, or as epoch seconds when s is all
+// digits; both read as UTC, like the layout branch
+func parseTimeOrEpoch(s, layout string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n >= minEpoch {
+		return time.Unix(n, 0).UTC(), nil
+	}
+	return time.Parse(layout, s)
 }
 
 // FormModel drives the multi‐field wizard
 type FormModel struct {
-	fields []Field
-	idx    int // which field is active
-	holder any // model instance being modified
+	fields    []Field
+	idx       int    // which field is active
+	holder    any    // model instance being modified
+	cancelled bool   // user quit before the last field
+	err       string // parse or validation message for the active field
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -134,6 +651,10 @@ func NewFormModel(fields []Field, holder any) FormModel {
 		ti := textinput.New()
 		ti.Placeholder = fields[i].Label
 		ti.SetValue(fields[i].Initial)
+		if fields[i].Mask {
+			ti.EchoMode = textinput.EchoPassword
+			ti.EchoCharacter = '•'
+		}
 		if i == 0 {
 			ti.Focus()
 		}
@@ -149,6 +670,21 @@ func NewFormModel(fields []Field, holder any) FormModel {
 func (m FormModel) Init() tea.Cmd { return nil }
 
 func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && (key.String() == "ctrl+c" || key.String() == "esc") {
+		m.cancelled = true
+		return m, tea.Quit
+	}
+	// step back to the previous field; each input keeps its typed text
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "shift+tab" {
+		if m.idx > 0 {
+			m.fields[m.idx].Input.Blur()
+			m.idx--
+			m.err = ""
+			return m, m.fields[m.idx].Input.Focus()
+		}
+		return m, nil
+	}
+
 	f := &m.fields[m.idx]
 	// Let the textinput handle keystrokes
 	ti, cmd := f.Input.Update(msg)
@@ -156,12 +692,25 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	if key, ok := msg.(tea.KeyMsg); ok && key.String() == "enter" {
 		raw := f.Input.Value()
+		if f.Required && strings.TrimSpace(raw) == "" {
+			m.err = "required, cannot be blank"
+			return m, nil
+		}
 		val, err := f.Parse(raw)
 		if err != nil {
-			// Here you might flash an error message instead of skipping
+			// stay on the field, saying why enter did nothing
+			m.err = err.Error()
 			return m, nil
 		}
 
+		if f.Validate != nil {
+			if err := f.Validate(m.holder, raw); err != nil {
+				m.err = err.Error()
+				return m, nil
+			}
+		}
+		m.err = ""
+
 		// Assign the parsed value into the holder via reflect
 		f.Assign(m.holder, val)
 
@@ -171,8 +720,8 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		// Focus next field
-		m.fields[m.idx].Input.Focus()
-		return m, nil
+		f.Input.Blur()
+		return m, m.fields[m.idx].Input.Focus()
 	}
 
 	return m, cmd
@@ -182,34 +731,151 @@ func (m FormModel) View() string {
 	if m.idx >= len(m.fields) {
 		return ""
 	}
-	f := m.fields[m.idx]
-	header := fmt.Sprintf("[%d/%d] %s\n\n", m.idx+1, len(m.fields), f.Label)
-	footer := "\n\n(enter to confirm, ctrl+c to cancel)"
-	return header + f.Input.View() + footer
+	// every field is listed so earlier answers stay visible; the active one
+	// is marked and carries its hint & input
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%d/%d]\n\n", m.idx+1, len(m.fields))
+	for i, f := range m.fields {
+		label := f.Label
+		if f.Required {
+			label += " *"
+		}
+		if i != m.idx {
+			value := f.Input.Value()
+			if f.Mask {
+				value = strings.Repeat("•", utf8.RuneCountInString(value))
+			}
+			fmt.Fprintf(&b, "  %s: %s\n", label, value)
+			continue
+		}
+		fmt.Fprintf(&b, "> %s\n", label)
+		if f.Hint != "" {
+			fmt.Fprintf(&b, "      %s\n", f.Hint)
+		}
+		fmt.Fprintf(&b, "    %s\n", f.Input.View())
+		if f.Help != "" {
+			help := f.Help
+			if colorEnabled() {
+				help = chalk.Dim.TextStyle(help)
+			}
+			fmt.Fprintf(&b, "      %s\n", help)
+		}
+	}
+	if m.err != "" {
+		msg := m.err
+		if colorEnabled() {
+			msg = chalk.Red.Color(msg)
+		}
+		b.WriteString("\n" + msg + "\n")
+	}
+	b.WriteString("\n(* required; enter to confirm, shift+tab to go back, ctrl+c to cancel)")
+	return b.String()
 }
 
-func RunFormWizard(fields []Field, holder any) {
+// RunFormWizard runs the wizard over fields; key names its draft, e.g. "event-add".
+// It reports false when the user cancelled, after saving the typed values as a
+// draft that the next run offers to resume; callers must then skip the write.
+func RunFormWizard(key string, fields []Field, holder any) bool {
+	restoreDraft(key, fields)
+
 	p := tea.NewProgram(NewFormModel(fields, holder))
-	if _, err := p.StartReturningModel(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		log.Fatalf("form wizard failed: %v", err)
 	}
+
+	m := final.(FormModel)
+	if m.cancelled {
+		path, err := saveDraft(key, m.fields)
+		if err != nil {
+			log.Fatalf("cancelled; saving draft failed: %v", err)
+		}
+		fmt.Printf("cancelled; draft saved to %s\n", path)
+		return false
+	}
+	_ = os.Remove(draftPath(key))
+	return true
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// RunFormWizardWithSubmit runs the wizard, then onSubmit to persist holder.
+// warn, when set, returns advisory text for the filled holder (e.g. an odd
+// field combination); a non-empty warning asks before saving. It reports
+// whether onSubmit ran.
 func RunFormWizardWithSubmit(
+	key string,
 	fields []Field,
 	holder any,
+	warn func(holder any) string,
 	onSubmit func(holder any) error,
-) {
-	p := tea.NewProgram(NewFormModel(fields, holder))
-	if _, err := p.StartReturningModel(); err != nil {
-		log.Fatalf("form wizard failed: %v", err)
+) bool {
+	if !RunFormWizard(key, fields, holder) {
+		return false
+	}
+	if warn != nil {
+		if msg := warn(holder); msg != "" {
+			fmt.Println("warning: " + msg)
+			if !confirm("save anyway?") {
+				fmt.Println("nothing saved")
+				return false
+			}
+		}
 	}
 	// once the wizard quits, run your Insert or Update
 	if err := onSubmit(holder); err != nil {
 		log.Fatalf("submit failed: %v", err)
 	}
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// draftPath is where the in-progress values of wizard key are kept
+func draftPath(key string) string {
+	return filepath.Join(os.TempDir(), "zenith-drafts", key+".json")
+}
+
+// saveDraft stores the current input of every field, keyed by label
+func saveDraft(key string, fields []Field) (string, error) {
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if f.Mask {
+			continue
+		}
+		values[f.Label] = f.Input.Value()
+	}
+	raw, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := draftPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, raw, 0o600)
+}
+
+// restoreDraft offers a saved draft and, if accepted, uses it as the fields' initial values
+func restoreDraft(key string, fields []Field) {
+	raw, err := os.ReadFile(draftPath(key))
+	if err != nil {
+		return
+	}
+	var values map[string]string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return
+	}
+	if !confirm("resume previous draft?") {
+		_ = os.Remove(draftPath(key))
+		return
+	}
+	for i := range fields {
+		if v, ok := values[fields[i].Label]; ok {
+			fields[i].Initial = v
+		}
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
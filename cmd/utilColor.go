@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/ttacon/chalk"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal
+func stdoutIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal, i.e. a prompt can be answered
+func stdinIsTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd())
+}
+
+// colorEnabled reports whether output may carry ANSI colors: stdout is a
+// terminal, NO_COLOR is unset (https://no-color.org) and TERM is not dumb
+func colorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return stdoutIsTerminal()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorByAge styles s by how recent t is: bold for today, plain for the past
+// week or anything upcoming, dimmed when older
+func colorByAge(s string, t, now time.Time) string {
+	t, now = t.Local(), now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case !t.Before(today) && t.Before(today.AddDate(0, 0, 1)):
+		return chalk.Bold.TextStyle(s)
+	case t.Before(today.AddDate(0, 0, -7)):
+		return chalk.Dim.TextStyle(s)
+	default:
+		return s
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
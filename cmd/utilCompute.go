@@ -0,0 +1,119 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// computedColumn is one --compute name=fn(column) entry
+type computedColumn struct {
+	name   string
+	fn     string
+	column string
+}
+
+// computeFuncs is the whole expression language: one function over one column
+var computeFuncs = map[string]func(string) (string, error){
+	"days_since": daysSince,
+	"upper":      func(s string) (string, error) { return strings.ToUpper(s), nil },
+	"lower":      func(s string) (string, error) { return strings.ToLower(s), nil },
+	"length":     func(s string) (string, error) { return strconv.Itoa(len([]rune(s))), nil },
+}
+
+var computeName = regexp.MustCompile(`^\w+$`)
+
+var computeExpr = regexp.MustCompile(`^\s*(\w+)\s*\(\s*(\w+)\s*\)\s*$`)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseComputed reads --compute entries such as age_days=days_since(created)
+func parseComputed(entries []string) ([]computedColumn, error) {
+	tables := [][]string{orgColumns, contactColumns, eventColumns, taskColumns}
+	var out []computedColumn
+	for _, e := range entries {
+		name, expr, ok := strings.Cut(e, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !computeName.MatchString(name) {
+			return nil, fmt.Errorf("invalid --compute %q, expected name=fn(column)", e)
+		}
+		m := computeExpr.FindStringSubmatch(expr)
+		if m == nil {
+			return nil, fmt.Errorf("invalid --compute expression %q, expected fn(column)", expr)
+		}
+		if _, known := computeFuncs[m[1]]; !known {
+			return nil, fmt.Errorf("unknown --compute function %q (valid: days_since, upper, lower, length)", m[1])
+		}
+		if !slices.ContainsFunc(tables, func(cols []string) bool { return slices.Contains(cols, m[2]) }) {
+			return nil, fmt.Errorf("unknown --compute column %q", m[2])
+		}
+		if slices.ContainsFunc(tables, func(cols []string) bool { return slices.Contains(cols, name) }) {
+			return nil, fmt.Errorf("--compute name %q clashes with an existing column", name)
+		}
+		out = append(out, computedColumn{name: name, fn: m[1], column: m[2]})
+	}
+	return out, nil
+}
+
+// appendComputed adds the computed columns a table has inputs for; entries
+// whose column the table lacks are skipped, so --all works with mixed tables
+func appendComputed(header []string, records [][]string, computed []computedColumn) ([]string, [][]string, error) {
+	for _, c := range computed {
+		idx := slices.Index(header, c.column)
+		if idx < 0 {
+			continue
+		}
+		header = append(slices.Clip(header), c.name)
+		fn := computeFuncs[c.fn]
+		for i, record := range records {
+			v := ""
+			if record[idx] != "" {
+				var err error
+				if v, err = fn(record[idx]); err != nil {
+					return nil, nil, fmt.Errorf("%s: %w", c.name, err)
+				}
+			}
+			records[i] = append(record, v)
+		}
+	}
+	return header, records, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// daysSince counts whole days from an RFC3339 timestamp or YYYY-MM-DD date until now
+func daysSince(s string) (string, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", s); err != nil {
+			return "", fmt.Errorf("days_since: %q is not a date", s)
+		}
+	}
+	return strconv.Itoa(int(math.Floor(time.Since(t).Hours() / 24))), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,8 +5,10 @@ package cmd
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/spf13/cobra"
 	"github.com/ttacon/chalk"
 )
 
@@ -59,26 +61,47 @@ var exampleRoot = formatExample(
 var exampleMigrate = formatExample(
 	"zenith",
 	[]string{"migrate"},
+	[]string{"migrate", "down", "2"},
+	[]string{"migrate", "status"},
 )
 
-var exampleOrg = formatExample(
-	"zenith",
-	[]string{"migrate"},
-)
-
-var exampleContact = formatExample(
-	"",
-	[]string{"contact"},
-)
-
-var exampleEvent = formatExample(
-	"",
-	[]string{"contact"},
-)
+// entityExample lists one sample invocation per registered subcommand of parent,
+// descending into nested groups (e.g. event attendees); placeholders such as
+// [id] become sample IDs 1, 2, ... so the examples track the commands as they change
+func entityExample(parent *cobra.Command) string {
+	var usages [][]string
+	var walk func(cmd *cobra.Command, path string)
+	walk = func(cmd *cobra.Command, path string) {
+		for _, sub := range cmd.Commands() {
+			if !sub.IsAvailableCommand() || sub.Name() == "help" {
+				continue
+			}
+			name := path + " " + sub.Name()
+			if sub.HasAvailableSubCommands() {
+				walk(sub, name)
+				continue
+			}
+			usage := []string{name}
+			n := 0
+			for _, tok := range strings.Fields(sub.Use)[1:] {
+				if strings.HasPrefix(tok, "[") {
+					n++
+					usage = append(usage, strconv.Itoa(n))
+				}
+			}
+			usages = append(usages, usage)
+		}
+	}
+	walk(parent, parent.Name())
+	return formatExample("zenith", usages...)
+}
 
-var exampleTask = formatExample(
-	"",
-	[]string{"contact"},
-)
+// setEntityExamples fills in the entity examples once every init has registered
+// its subcommands; cobra renders --help before any OnInitialize hook runs
+func setEntityExamples() {
+	for _, cmd := range []*cobra.Command{orgCmd, contactCmd, eventCmd, taskCmd} {
+		cmd.Example = entityExample(cmd)
+	}
+}
 
-////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,691 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// errEmptyExport is returned after writing a table with no rows under --fail-on-empty
+var errEmptyExport = errors.New("no rows exported")
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tableExport writes one table to its output file as pages of rows arrive,
+// so an export holds a single page in memory rather than the whole table.
+// Under --page-size it rolls over to the next numbered file every N rows;
+// --append still collects the rows, since it merges into the previous file
+type tableExport struct {
+	stem     string     // output path without extension, under --out-dir
+	fetched  []string   // columns as queried
+	header   []string   // fetched plus --compute columns, before selection
+	idIdx    int        // index of the id read for --since-id, -1 when absent
+	file     recordFile // open output file in streaming mode
+	chunk    int        // number of the open --page-size file, from 1
+	fileRows int        // rows written to the open file
+	held     [][]string // rows collected under --append
+	total    int        // rows the query matches, counted up front for progress
+	written  int        // rows written to files so far
+	rows     int
+	maxID    int64
+}
+
+// newTableExport opens the output for one table
+//
+//	stem:  file name without extension, e.g. "organizations"
+//	total: rows the query matches, as counted by countRows
+func newTableExport(stem string, header []string, total int) (*tableExport, error) {
+	computed, _, err := appendComputed(header, nil, exportComputed)
+	if err != nil {
+		return nil, err
+	}
+	t := &tableExport{
+		stem:    filepath.Join(exportOutDir, stem),
+		fetched: header,
+		header:  computed,
+		idIdx:   slices.Index(header, "id"),
+		total:   total,
+		maxID:   exportSinceID,
+	}
+	if t.idIdx < 0 {
+		t.idIdx = slices.Index(header, "task_id") // export joined
+	}
+	if t.buffered() {
+		return t, nil
+	}
+	// the first file is opened up front so an empty table still gets one
+	if err := t.open(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// buffered reports whether rows are held until finish instead of streamed
+func (t *tableExport) buffered() bool {
+	return exportFormat == "json" && exportAppend
+}
+
+// open starts the next output file: stem.csv / stem.json, or the next
+// numbered stem.0001.json chunk under --page-size
+func (t *tableExport) open() error {
+	out, _ := selectColumns(t.header, nil)
+	var err error
+	remaining := t.total - t.written
+	switch {
+	case exportFormat != "json":
+		t.file, err = openCSVFile(t.stem+".csv", out, remaining)
+	case exportPageSize > 0:
+		t.chunk++
+		t.file, err = openJSONFile(fmt.Sprintf("%s.%04d.json", t.stem, t.chunk), out, min(remaining, exportPageSize))
+	default:
+		t.file, err = openJSONFile(t.stem+".json", out, remaining)
+	}
+	t.fileRows = 0
+	return err
+}
+
+// write computes, redacts and selects columns for one page, then writes it
+func (t *tableExport) write(records [][]string) error {
+	_, records, err := appendComputed(t.fetched, records, exportComputed)
+	if err != nil {
+		return err
+	}
+	if exportSinceIDSet {
+		// read before --exclude-columns may drop the id
+		for _, record := range records {
+			if id, err := strconv.ParseInt(record[t.idIdx], 10, 64); err == nil && id > t.maxID {
+				t.maxID = id
+			}
+		}
+	}
+	redact(t.header, records)
+	_, records = selectColumns(t.header, records)
+	t.rows += len(records)
+
+	if t.buffered() {
+		t.held = append(t.held, records...)
+		return nil
+	}
+	for _, record := range records {
+		// numbered chunks keep each file bounded for incremental consumers
+		if exportPageSize > 0 && t.fileRows == exportPageSize {
+			if err := t.closeFile(); err != nil {
+				return err
+			}
+			if err := t.open(); err != nil {
+				return err
+			}
+		}
+		if err := t.file.write(record); err != nil {
+			return err
+		}
+		t.fileRows++
+		t.written++
+	}
+	return nil
+}
+
+// finish completes the output file(s) and reports --since-id & --fail-on-empty
+func (t *tableExport) finish() error {
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if exportSinceIDSet {
+		fmt.Printf("%s: max id %d\n", filepath.Base(t.stem), t.maxID)
+	}
+	if exportNoEmpty && t.rows == 0 {
+		return errEmptyExport
+	}
+	return nil
+}
+
+// flush closes the streamed file, or merges the held rows under --append
+func (t *tableExport) flush() error {
+	if !t.buffered() {
+		return t.closeFile()
+	}
+
+	header, _ := selectColumns(t.header, nil)
+	merged, err := mergeJSONFile(t.stem+".json", header, t.held)
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(t.stem+".json", header, merged)
+}
+
+// closeFile finishes the open file, leaving none for abort to release
+func (t *tableExport) closeFile() error {
+	file := t.file
+	t.file = nil
+	return file.close()
+}
+
+// abort releases the open file after a failed export; safe after finish
+func (t *tableExport) abort() {
+	if t.file != nil {
+		t.file.abort()
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// redactTransforms are the masks selectable with --redact column=name
+var redactTransforms = map[string]func(string) string{
+	"mask-email": maskEmail,
+	"hash":       hashValue,
+	"truncate":   truncateValue,
+}
+
+// parseRedactions reads --redact entries into column → transform name;
+// computed columns may be redacted too
+func parseRedactions(entries []string, computed []computedColumn) (map[string]string, error) {
+	masks := map[string]string{}
+	for _, e := range entries {
+		col, name, ok := strings.Cut(e, "=")
+		if !ok {
+			name = "mask-email"
+		}
+		if _, known := redactTransforms[name]; !known {
+			return nil, fmt.Errorf("unknown --redact transform %q (valid: mask-email, hash, truncate)", name)
+		}
+		isComputed := slices.ContainsFunc(computed, func(c computedColumn) bool { return c.name == col })
+		if !isComputed && !slices.ContainsFunc([][]string{orgColumns, contactColumns, eventColumns, taskColumns, joinedColumns}, func(cols []string) bool {
+			return slices.Contains(cols, col)
+		}) {
+			return nil, fmt.Errorf("unknown --redact column %q", col)
+		}
+		masks[col] = name
+	}
+	return masks, nil
+}
+
+// redact applies the --redact transforms in place; empty cells stay empty
+func redact(header []string, records [][]string) {
+	for i, col := range header {
+		name, ok := exportMasks[col]
+		if !ok {
+			continue
+		}
+		transform := redactTransforms[name]
+		for _, record := range records {
+			if record[i] != "" {
+				record[i] = transform(record[i])
+			}
+		}
+	}
+}
+
+// checkColumnSelection rejects --columns / --exclude-columns names unknown to
+// every table, and selections that would drop the id --append merges on
+func checkColumnSelection(computed []computedColumn) error {
+	names := exportColumns
+	if len(exportExclude) > 0 {
+		names = exportExclude
+	}
+	for _, col := range names {
+		isComputed := slices.ContainsFunc(computed, func(c computedColumn) bool { return c.name == col })
+		_, isResolved := resolvedColumns[col]
+		if !isComputed && !isResolved && !slices.ContainsFunc([][]string{orgColumns, contactColumns, eventColumns, taskColumns, joinedColumns}, func(cols []string) bool {
+			return slices.Contains(cols, col)
+		}) {
+			return fmt.Errorf("unknown column %q", col)
+		}
+	}
+	if exportAppend && ((len(exportColumns) > 0 && !slices.Contains(exportColumns, "id")) || slices.Contains(exportExclude, "id")) {
+		return fmt.Errorf("--append needs the id column")
+	}
+	return nil
+}
+
+// selectColumns applies --columns / --exclude-columns, keeping table order
+func selectColumns(header []string, records [][]string) ([]string, [][]string) {
+	if len(exportColumns) == 0 && len(exportExclude) == 0 {
+		return header, records
+	}
+	var keep []int
+	for i, col := range header {
+		if len(exportColumns) > 0 && !slices.Contains(exportColumns, col) || slices.Contains(exportExclude, col) {
+			continue
+		}
+		keep = append(keep, i)
+	}
+
+	pick := func(row []string) []string {
+		out := make([]string, len(keep))
+		for j, i := range keep {
+			out[j] = row[i]
+		}
+		return out
+	}
+	for r, record := range records {
+		records[r] = pick(record)
+	}
+	return pick(header), records
+}
+
+// maskEmail keeps the first character and the domain: jane@acme.com → j***@acme.com
+func maskEmail(s string) string {
+	local, domain, ok := strings.Cut(s, "@")
+	masked := string([]rune(local)[:1]) + "***"
+	if ok {
+		masked += "@" + domain
+	}
+	return masked
+}
+
+// hashValue replaces s with the first 12 hex digits of its sha256, stable across exports
+func hashValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// truncateValue keeps the first 4 characters
+func truncateValue(s string) string {
+	if r := []rune(s); len(r) > 4 {
+		return string(r[:4])
+	}
+	return s
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// exportFile is one output file, gzip-compressed under --gzip. Close flushes
+// the gzip trailer before closing the file, so it is also safe to defer on
+// error paths: a partial export still ends in a well-formed archive
+type exportFile struct {
+	io.Writer
+	name   string // final name, with .gz under --gzip
+	file   *os.File
+	gz     *gzip.Writer
+	closed bool
+}
+
+func createExportFile(name string) (*exportFile, error) {
+	if exportGzip {
+		name += ".gz"
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", name, err)
+	}
+	f := &exportFile{Writer: file, name: name, file: file}
+	if exportGzip {
+		f.gz = gzip.NewWriter(file)
+		f.gz.Name = strings.TrimSuffix(filepath.Base(name), ".gz")
+		f.Writer = f.gz
+	}
+	return f, nil
+}
+
+// Close closes the gzip layer, then the file; later calls are no-ops
+func (f *exportFile) Close() error {
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	var err error
+	if f.gz != nil {
+		err = f.gz.Close()
+	}
+	if cerr := f.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// recordFile is one open export file taking rows one at a time
+type recordFile interface {
+	write(record []string) error
+	close() error // finishes the file and prints "exported <name>"
+	abort()       // closes without finishing, after a failure
+}
+
+// csvFile streams records to a CSV export file
+type csvFile struct {
+	file *exportFile
+	w    csvRecordWriter
+	bar  *progress
+	rows int
+}
+
+func openCSVFile(name string, header []string, total int) (*csvFile, error) {
+	file, err := createExportFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if exportBOM {
+		if _, err := io.WriteString(file, "\ufeff"); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("write %s: %w", file.name, err)
+		}
+	}
+
+	cw := csv.NewWriter(file)
+	cw.UseCRLF = exportLineEnding == "crlf"
+	var w csvRecordWriter = cw
+	if exportQuoteAll {
+		w = &quoteAllWriter{w: bufio.NewWriter(file), eol: lineEnding()}
+	}
+	if err := w.Write(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &csvFile{file: file, w: w, bar: newProgress(file.name, "exported", total, exportQuiet)}, nil
+}
+
+func (f *csvFile) write(record []string) error {
+	if err := f.w.Write(record); err != nil {
+		return err
+	}
+	f.rows++
+	f.bar.update(f.rows)
+	return nil
+}
+
+func (f *csvFile) close() error {
+	f.bar.finish()
+	f.w.Flush()
+	if err := f.w.Error(); err != nil {
+		f.file.Close()
+		return fmt.Errorf("write %s: %w", f.file.name, err)
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", f.file.name, err)
+	}
+
+	if !exportQuiet {
+		fmt.Println("exported " + f.file.name)
+	}
+	return nil
+}
+
+func (f *csvFile) abort() {
+	f.bar.finish()
+	f.file.Close()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// lineEnding returns the record terminator selected by --line-ending
+func lineEnding() string {
+	if exportLineEnding == "crlf" {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// csvRecordWriter is the subset of csv.Writer used by csvFile
+type csvRecordWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+// quoteAllWriter writes CSV with every field quoted, for --quote-all;
+// encoding/csv only quotes fields that need it
+type quoteAllWriter struct {
+	w   *bufio.Writer
+	eol string
+	err error
+}
+
+func (q *quoteAllWriter) Write(record []string) error {
+	if q.err != nil {
+		return q.err
+	}
+	for i, field := range record {
+		if i > 0 {
+			q.w.WriteByte(',')
+		}
+		q.w.WriteByte('"')
+		q.w.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		q.w.WriteByte('"')
+	}
+	_, q.err = q.w.WriteString(q.eol)
+	return q.err
+}
+
+func (q *quoteAllWriter) Flush() {
+	if q.err == nil {
+		q.err = q.w.Flush()
+	}
+}
+
+func (q *quoteAllWriter) Error() error { return q.err }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// jsonFile streams records to a JSON export file as an array of objects,
+// one per line, keeping keys in column order
+type jsonFile struct {
+	file   *exportFile
+	w      *bufio.Writer
+	header []string
+	eol    string
+	bar    *progress
+	rows   int
+}
+
+func openJSONFile(name string, header []string, total int) (*jsonFile, error) {
+	file, err := createExportFile(name)
+	if err != nil {
+		return nil, err
+	}
+	f := &jsonFile{file: file, w: bufio.NewWriter(file), header: header, eol: lineEnding(), bar: newProgress(file.name, "exported", total, exportQuiet)}
+	f.w.WriteString("[" + f.eol)
+	return f, nil
+}
+
+func (f *jsonFile) write(record []string) error {
+	obj, err := jsonObject(f.header, record)
+	if err != nil {
+		return err
+	}
+	// the separator goes before every object but the first, so none trails the last
+	if f.rows > 0 {
+		f.w.WriteString("," + f.eol)
+	}
+	if _, err := f.w.Write(obj); err != nil {
+		return fmt.Errorf("write %s: %w", f.file.name, err)
+	}
+	f.rows++
+	f.bar.update(f.rows)
+	return nil
+}
+
+func (f *jsonFile) close() error {
+	f.bar.finish()
+	if f.rows > 0 {
+		f.w.WriteString(f.eol)
+	}
+	f.w.WriteString("]" + f.eol)
+	if err := f.w.Flush(); err != nil {
+		f.file.Close()
+		return fmt.Errorf("write %s: %w", f.file.name, err)
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", f.file.name, err)
+	}
+
+	if !exportQuiet {
+		fmt.Println("exported " + f.file.name)
+	}
+	return nil
+}
+
+func (f *jsonFile) abort() {
+	f.bar.finish()
+	f.file.Close()
+}
+
+// writeJSONFile writes held records to name in one go, for --append
+func writeJSONFile(name string, header []string, records [][]string) error {
+	f, err := openJSONFile(name, header, len(records))
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := f.write(record); err != nil {
+			f.abort()
+			return err
+		}
+	}
+	return f.close()
+}
+
+// mergeJSONFile reads a previous export and folds records into it by id:
+// existing rows are replaced in place, unseen ones appended in query order.
+// A missing file is treated as empty
+func mergeJSONFile(name string, header []string, records [][]string) ([][]string, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	var objs []map[string]string
+	if err := json.Unmarshal(data, &objs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	// id is always the first column
+	merged := make([][]string, 0, len(objs)+len(records))
+	index := make(map[string]int, len(objs))
+	for _, obj := range objs {
+		record := make([]string, len(header))
+		for i, col := range header {
+			record[i] = obj[col]
+		}
+		index[record[0]] = len(merged)
+		merged = append(merged, record)
+	}
+	for _, record := range records {
+		if i, ok := index[record[0]]; ok {
+			merged[i] = record
+			continue
+		}
+		index[record[0]] = len(merged)
+		merged = append(merged, record)
+	}
+	return merged, nil
+}
+
+// resolvedColumns maps each --resolve name column to the foreign key it describes
+var resolvedColumns = map[string]string{
+	"org_name":     "org",
+	"contact_name": "contact",
+}
+
+// jsonObject renders one record as {"column": "value", ...}; under --nested a
+// resolved foreign key becomes {"org": {"id": "1", "name": "Acme"}, ...}
+func jsonObject(header, record []string) ([]byte, error) {
+	nested := map[string]int{} // foreign key column → index of its name column
+	if exportNested {
+		for i, col := range header {
+			if fk, ok := resolvedColumns[col]; ok {
+				nested[fk] = i
+			}
+		}
+	}
+
+	buf := []byte{'{'}
+	first := true
+	for i, col := range header {
+		if _, isName := resolvedColumns[col]; isName && exportNested {
+			continue
+		}
+		k, err := json.Marshal(col)
+		if err != nil {
+			return nil, err
+		}
+		var v []byte
+		if nameIdx, ok := nested[col]; ok {
+			v, err = jsonObject([]string{"id", "name"}, []string{record[i], record[nameIdx]})
+		} else {
+			v, err = json.Marshal(record[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = append(buf, k...)
+		buf = append(buf, ':')
+		buf = append(buf, v...)
+	}
+	return append(buf, '}'), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// progressInterval throttles progress redraws to avoid flicker
+const progressInterval = 200 * time.Millisecond
+
+// progress redraws "name: exported N / total rows" in place while a file is
+// written or read back; it stays silent under --quiet or when stdout is not
+// a terminal. Export streams rows, so its total comes from a COUNT run beforehand
+type progress struct {
+	name   string
+	verb   string
+	total  int
+	active bool
+	last   time.Time
+}
+
+func newProgress(name, verb string, total int, quiet bool) *progress {
+	return &progress{name: name, verb: verb, total: total, active: stdoutIsTerminal() && !quiet, last: time.Now()}
+}
+
+func (p *progress) update(done int) {
+	if !p.active || time.Since(p.last) < progressInterval {
+		return
+	}
+	p.last = time.Now()
+	fmt.Printf("\r%s: %s %s / %s rows", p.name, p.verb, humanCount(done), humanCount(p.total))
+}
+
+// finish clears the progress line so the summary prints on a clean line
+func (p *progress) finish() {
+	if p.active {
+		fmt.Print("\r\033[K")
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -45,25 +45,25 @@ var helpMigrate = formatHelp(
 var helpOrg = formatHelp(
 	"Daniel Rivas",
 	"danielrivasmd@gmail.com",
-	"Apply any pending up or down migration scripts against the configured SQLite database",
+	"Manage organizations: the companies & institutions contacts belong to",
 )
 
 var helpContact = formatHelp(
 	"Daniel Rivas",
-	"<danielrivasmd@gmail.com>",
-	"",
+	"danielrivasmd@gmail.com",
+	"Manage contacts: people at an organization, with role, email, LinkedIn & phone",
 )
 
 var helpEvent = formatHelp(
 	"Daniel Rivas",
-	"<danielrivasmd@gmail.com>",
-	"",
+	"danielrivasmd@gmail.com",
+	"Manage events: dated interactions with a contact, plus any additional attendees",
 )
 
 var helpTask = formatHelp(
 	"Daniel Rivas",
-	"<danielrivasmd@gmail.com>",
-	"",
+	"danielrivasmd@gmail.com",
+	"Manage tasks: follow-ups with a due date & status, optionally tied to an event",
 )
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
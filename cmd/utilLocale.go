@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// displayTag is the --locale / locale config used for human-facing output;
+// language.Und keeps the ISO dates & plain numbers. Exports never use it.
+var displayTag = language.Und
+
+// setDisplayLocale parses a BCP 47 tag such as "de-DE" or "en-GB"; "" resets to ISO
+func setDisplayLocale(s string) error {
+	if s == "" {
+		displayTag = language.Und
+		return nil
+	}
+	tag, err := language.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", s, err)
+	}
+	displayTag = tag
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// dateLayout picks the day/month order & separator for displayTag's region
+func dateLayout() string {
+	if displayTag == language.Und {
+		return "2006-01-02"
+	}
+	region, _ := displayTag.Region()
+	switch region.String() {
+	case "US", "PH":
+		return "01/02/2006"
+	case "AT", "CH", "CZ", "DE", "DK", "FI", "NO", "PL", "RU", "TR":
+		return "02.01.2006"
+	case "CA", "CN", "JP", "KR", "LT", "SE":
+		return "2006-01-02"
+	default:
+		return "02/01/2006"
+	}
+}
+
+// humanDate formats t for display in the selected locale
+func humanDate(t time.Time) string {
+	return t.Format(dateLayout())
+}
+
+// humanDateTime formats t with a 24h clock for display in the selected locale
+func humanDateTime(t time.Time) string {
+	return t.Format(dateLayout() + " 15:04")
+}
+
+// humanCount renders n with the locale's thousands separator (1,234 / 1.234)
+func humanCount(n int) string {
+	if displayTag == language.Und {
+		return fmt.Sprint(n)
+	}
+	return message.NewPrinter(displayTag).Sprintf("%d", n)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// lookupChunk bounds the ids bound into one IN (...) query
+const lookupChunk = 500
+
+// nameLookup is a read-through cache of id → name for one table (orgs or
+// contacts), so resolving foreign keys costs one query per batch of new ids
+// instead of one per row
+type nameLookup struct {
+	table string
+	names map[int64]string
+}
+
+func newNameLookup(table string) *nameLookup {
+	return &nameLookup{table: table, names: map[int64]string{}}
+}
+
+// load fetches the ids not cached yet; ids without a row are cached as ""
+func (l *nameLookup) load(ctx context.Context, conn *sql.DB, ids []int64) error {
+	var missing []any
+	for _, id := range ids {
+		if _, ok := l.names[id]; !ok && id != 0 {
+			l.names[id] = ""
+			missing = append(missing, id)
+		}
+	}
+
+	for len(missing) > 0 {
+		n := min(len(missing), lookupChunk)
+		query := fmt.Sprintf("SELECT id, name FROM %s WHERE id IN (?%s)", l.table, strings.Repeat(", ?", n-1))
+		rows, err := conn.QueryContext(ctx, query, missing[:n]...)
+		if err != nil {
+			return fmt.Errorf("look up %s: %w", l.table, err)
+		}
+		for rows.Next() {
+			var id int64
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				rows.Close()
+				return fmt.Errorf("look up %s: %w", l.table, err)
+			}
+			l.names[id] = name
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("look up %s: %w", l.table, err)
+		}
+		missing = missing[n:]
+	}
+	return nil
+}
+
+// name returns the cached name for id, "" when unknown or not loaded
+func (l *nameLookup) name(id int64) string {
+	return l.names[id]
+}
+
+// collectIDs extracts the foreign key of every row
+func collectIDs[T any](rows []T, key func(T) int64) []int64 {
+	ids := make([]int64, len(rows))
+	for i, r := range rows {
+		ids[i] = key(r)
+	}
+	return ids
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
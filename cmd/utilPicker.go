@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pickItem is one selectable record: its ID and the desc.Format summary
+type pickItem struct {
+	id    int64
+	label string
+}
+
+func (i pickItem) FilterValue() string { return i.label }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pickDelegate renders one line per item, with a checkbox in multi-select mode
+type pickDelegate struct {
+	multi    bool
+	selected map[int64]bool // shared with pickerModel
+}
+
+func (d pickDelegate) Height() int                               { return 1 }
+func (d pickDelegate) Spacing() int                              { return 0 }
+func (d pickDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
+
+func (d pickDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	it, ok := item.(pickItem)
+	if !ok {
+		return
+	}
+	cursor := "  "
+	if index == m.Index() {
+		cursor = "> "
+	}
+	box := ""
+	if d.multi {
+		box = "[ ] "
+		if d.selected[it.id] {
+			box = "[x] "
+		}
+	}
+	fmt.Fprintf(w, "%s%s%d  %s", cursor, box, it.id, it.label)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// pickerModel wraps bubbles/list for single or multi selection
+type pickerModel struct {
+	list      list.Model
+	multi     bool
+	selected  map[int64]bool
+	cancelled bool
+}
+
+func newPickerModel(title string, items []pickItem, multi bool) pickerModel {
+	selected := map[int64]bool{}
+	listItems := make([]list.Item, len(items))
+	for i, it := range items {
+		listItems[i] = it
+	}
+
+	l := list.New(listItems, pickDelegate{multi: multi, selected: selected}, 80, 20)
+	l.Title = title
+	// quitting is handled here so cancel can be told apart from confirm
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ForceQuit.SetEnabled(false)
+
+	help := []key.Binding{
+		key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+	}
+	if multi {
+		help = append(help, key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")))
+	}
+	l.AdditionalShortHelpKeys = func() []key.Binding { return help }
+
+	return pickerModel{list: l, multi: multi, selected: selected}
+}
+
+func (m pickerModel) Init() tea.Cmd { return nil }
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		// while typing a filter every key belongs to the list
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, tea.Quit
+		case " ":
+			if it, ok := m.list.SelectedItem().(pickItem); ok && m.multi {
+				m.selected[it.id] = !m.selected[it.id]
+			}
+			return m, nil
+		case "enter":
+			if it, ok := m.list.SelectedItem().(pickItem); ok && !m.multi {
+				m.selected[it.id] = true
+			}
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	return m.list.View()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// runPicker shows items and returns the chosen IDs in ascending order;
+// ok is false when the user cancelled
+func runPicker(title string, items []pickItem, multi bool) (ids []int64, ok bool) {
+	final, err := tea.NewProgram(newPickerModel(title, items, multi)).Run()
+	if err != nil {
+		log.Fatalf("picker failed: %v", err)
+	}
+	m := final.(pickerModel)
+	if m.cancelled {
+		return nil, false
+	}
+	for id, on := range m.selected {
+		if on {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids, true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,76 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	cpuProfile string // hidden --cpuprofile: pprof CPU profile written here
+	memProfile string // hidden --memprofile: pprof heap profile written here
+	cpuFile    *os.File
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// startProfiling begins CPU profiling once flags are parsed;
+// inspect the output with: go tool pprof zenith cpu.out
+func startProfiling() {
+	if cpuProfile == "" {
+		return
+	}
+	f, err := os.Create(cpuProfile)
+	if err != nil {
+		log.Fatalf("create CPU profile: %v", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		log.Fatalf("start CPU profile: %v", err)
+	}
+	cpuFile = f
+}
+
+// stopProfiling flushes the CPU profile and writes the heap profile after the
+// command returns; commands exiting through log.Fatalf leave no profile
+func stopProfiling() {
+	if cpuFile != nil {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+		cpuFile = nil
+	}
+	if memProfile == "" {
+		return
+	}
+	f, err := os.Create(memProfile)
+	if err != nil {
+		log.Fatalf("create memory profile: %v", err)
+	}
+	defer f.Close()
+	runtime.GC() // up-to-date allocation statistics
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		log.Fatalf("write memory profile: %v", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
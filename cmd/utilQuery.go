@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+	"github.com/spf13/cobra"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// queryFlags holds the filter & sort flags shared by list and export
+type queryFlags struct {
+	where []string // column=value pairs
+	sort  string   // column to order by
+	desc  bool     // reverse the --sort or default order
+	since string   // YYYY-MM-DD lower bound on the date column
+	until string   // YYYY-MM-DD upper bound on the date column (inclusive)
+	last  string   // relative window ending now, e.g. 7d
+	next  string   // relative window starting now, e.g. 7d
+	limit int      // max rows, 0 = unbounded
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// registerQueryFlags binds the shared query flags onto cmd
+func registerQueryFlags(cmd *cobra.Command, qf *queryFlags) {
+	registerFilterFlags(cmd, qf)
+	cmd.Flags().StringVar(&qf.sort, "sort", "", "Order by column")
+	cmd.Flags().BoolVar(&qf.desc, "desc", false, "Reverse the order: descending by --sort, or the opposite of the default")
+	cmd.Flags().IntVar(&qf.limit, "limit", 0, "Maximum number of rows (0 = all)")
+}
+
+// registerFilterFlags binds only the row filters, for commands where order & limit mean nothing
+func registerFilterFlags(cmd *cobra.Command, qf *queryFlags) {
+	cmd.Flags().StringArrayVar(&qf.where, "where", nil, "Filter by column=value (repeatable)")
+	cmd.Flags().StringVar(&qf.since, "since", "", "Only rows dated on or after YYYY-MM-DD")
+	cmd.Flags().StringVar(&qf.until, "until", "", "Only rows dated on or before YYYY-MM-DD")
+	cmd.Flags().StringVar(&qf.last, "last", "", "Only rows dated within the past window, e.g. 12h, 7d, 2w")
+	cmd.Flags().StringVar(&qf.next, "next", "", "Only rows dated within the coming window, e.g. 12h, 7d, 2w")
+	cmd.MarkFlagsMutuallyExclusive("last", "next")
+}
+
+// registerSortCompletion offers columns as shell completions for --sort
+func registerSortCompletion(cmd *cobra.Command, columns []string) {
+	cmd.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return columns, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// buildQueryMods translates query flags into SQLBoiler query mods
+//
+//	columns:      allow-list of column names accepted by --where and --sort
+//	dateColumn:   column compared against --since / --until
+//	defaultOrder: ORDER BY used without --sort, "" meaning "id ASC"
+//
+// columns[0] is the key, appended as a final ORDER BY term so rows that tie
+// on the sort column keep one order across LIMIT/OFFSET pages
+func buildQueryMods(qf queryFlags, columns []string, dateColumn, defaultOrder string) ([]qm.QueryMod, error) {
+	var mods []qm.QueryMod
+
+	for _, w := range qf.where {
+		col, val, ok := strings.Cut(w, "=")
+		col = strings.TrimSpace(col)
+		if !ok || col == "" {
+			return nil, fmt.Errorf("invalid --where %q, expected column=value", w)
+		}
+		if !slices.Contains(columns, col) {
+			return nil, fmt.Errorf("unknown column %q (valid: %s)", col, strings.Join(columns, ", "))
+		}
+		mods = append(mods, qm.Where(col+" = ?", val))
+	}
+
+	var since time.Time
+	if qf.since != "" {
+		t, err := time.Parse("2006-01-02", qf.since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --since %q: %w", qf.since, err)
+		}
+		since = t
+		mods = append(mods, qm.Where(dateColumn+" >= ?", t))
+	}
+	if qf.until != "" {
+		t, err := time.Parse("2006-01-02", qf.until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --until %q: %w", qf.until, err)
+		}
+		// a reversed window would silently match nothing
+		if t.Before(since) {
+			return nil, fmt.Errorf("--until %s is before --since %s", qf.until, qf.since)
+		}
+		// until is inclusive of the whole day
+		mods = append(mods, qm.Where(dateColumn+" < ?", t.AddDate(0, 0, 1)))
+	}
+
+	if qf.last != "" || qf.next != "" {
+		now := time.Now().UTC()
+		if qf.last != "" {
+			d, err := parseWindow(qf.last)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --last %q: %w", qf.last, err)
+			}
+			mods = append(mods, qm.Where(dateColumn+" >= ?", now.Add(-d)), qm.Where(dateColumn+" <= ?", now))
+		}
+		if qf.next != "" {
+			d, err := parseWindow(qf.next)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --next %q: %w", qf.next, err)
+			}
+			mods = append(mods, qm.Where(dateColumn+" >= ?", now), qm.Where(dateColumn+" <= ?", now.Add(d)))
+		}
+	}
+
+	order := "id ASC"
+	if defaultOrder != "" {
+		order = defaultOrder
+	}
+	if qf.sort != "" {
+		if !slices.Contains(columns, qf.sort) {
+			return nil, fmt.Errorf("cannot sort by %q (valid: %s)", qf.sort, strings.Join(columns, ", "))
+		}
+		order = qf.sort + " ASC"
+	}
+	// --desc reverses the order, so a default that is already descending
+	// (events by occurred) flips to ascending instead of doing nothing
+	if qf.desc {
+		col, dir, _ := strings.Cut(order, " ")
+		if dir == "DESC" {
+			order = col + " ASC"
+		} else {
+			order = col + " DESC"
+		}
+	}
+	if col, _, _ := strings.Cut(order, " "); col != columns[0] {
+		order += ", " + columns[0] + " ASC"
+	}
+	mods = append(mods, qm.OrderBy(order))
+
+	if qf.limit > 0 {
+		mods = append(mods, qm.Limit(qf.limit))
+	}
+
+	return mods, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseWindow reads a relative window of hours, days or weeks: 12h, 7d, 2w
+func parseWindow(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("expected N followed by h, d or w")
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("expected a positive count before the unit")
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("unknown unit %q, expected h, d or w", s[len(s)-1:])
+	}
+	return time.Duration(n) * unit, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,297 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package cmd
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/null/v8"
+
+	"github.com/DanielRivasMD/Zenith/models"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Each table has a record function rendering a row as export cells, in the
+// order of its *Columns list, and a set function parsing one such cell back
+// for import. Keep the pairs in sync: whatever export writes, import reads.
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func orgRecord(o *models.Org) []string {
+	return []string{
+		strconv.FormatInt(o.ID.Int64, 10),
+		o.Name,
+		o.Location.String,
+		strconv.FormatInt(o.AllowDuplicateName, 10),
+		o.Created.Format(time.RFC3339),
+		o.Updated.Format(time.RFC3339),
+	}
+}
+
+func setOrgColumn(o *models.Org, col, val string) (err error) {
+	switch col {
+	case "name":
+		o.Name = val
+	case "location":
+		o.Location = cellString(val)
+	case "allow_duplicate_name":
+		o.AllowDuplicateName, err = cellInt(val)
+	case "created":
+		err = cellTime(&o.Created, val)
+	case "updated":
+		err = cellTime(&o.Updated, val)
+	default:
+		err = fmt.Errorf("unknown column")
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func contactRecord(c *models.Contact) []string {
+	return []string{
+		strconv.FormatInt(c.ID.Int64, 10),
+		strconv.FormatInt(c.Org, 10),
+		c.Name,
+		c.Role.String,
+		c.Email.String,
+		c.Linkedin.String,
+		c.Phone.String,
+		c.Created.Format(time.RFC3339),
+		c.Updated.Format(time.RFC3339),
+	}
+}
+
+func setContactColumn(c *models.Contact, col, val string) (err error) {
+	switch col {
+	case "org":
+		c.Org, err = cellInt(val)
+	case "name":
+		c.Name = val
+	case "role":
+		c.Role = cellString(val)
+	case "email":
+		c.Email = cellString(val)
+	case "linkedin":
+		c.Linkedin = cellString(val)
+	case "phone":
+		c.Phone = cellString(val)
+	case "created":
+		err = cellTime(&c.Created, val)
+	case "updated":
+		err = cellTime(&c.Updated, val)
+	default:
+		err = fmt.Errorf("unknown column")
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func eventRecord(e *models.Event) []string {
+	return []string{
+		strconv.FormatInt(e.ID.Int64, 10),
+		strconv.FormatInt(e.Contact, 10),
+		e.Occurred.Format(time.RFC3339),
+		e.Mode.String,
+		formatNullInt(e.Priority),
+		e.Context.String,
+		e.Description.String,
+		e.Action.String,
+		e.Comment.String,
+		e.Author.String,
+		e.Created.Format(time.RFC3339),
+		e.Updated.Format(time.RFC3339),
+	}
+}
+
+func setEventColumn(e *models.Event, col, val string) (err error) {
+	switch col {
+	case "contact":
+		e.Contact, err = cellInt(val)
+	case "occurred":
+		err = cellTime(&e.Occurred, val)
+	case "mode":
+		e.Mode = cellString(val)
+	case "priority":
+		e.Priority, err = cellNullInt(val)
+	case "context":
+		e.Context = cellString(val)
+	case "description":
+		e.Description = cellString(val)
+	case "action":
+		e.Action = cellString(val)
+	case "comment":
+		e.Comment = cellString(val)
+	case "author":
+		e.Author = cellString(val)
+	case "created":
+		err = cellTime(&e.Created, val)
+	case "updated":
+		err = cellTime(&e.Updated, val)
+	default:
+		err = fmt.Errorf("unknown column")
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func taskRecord(t *models.Task) []string {
+	return []string{
+		strconv.FormatInt(t.ID.Int64, 10),
+		formatNullInt(t.Interaction),
+		formatNullInt(t.Assigned),
+		t.Title,
+		formatNullTime(t.Duedate, "2006-01-02"),
+		t.Status.String,
+		t.Notes.String,
+		t.Created.Format(time.RFC3339),
+		t.Updated.Format(time.RFC3339),
+	}
+}
+
+func setTaskColumn(t *models.Task, col, val string) (err error) {
+	switch col {
+	case "interaction":
+		t.Interaction, err = cellNullKey(val)
+	case "assigned":
+		t.Assigned, err = cellNullKey(val)
+	case "title":
+		t.Title = val
+	case "duedate":
+		t.Duedate, err = cellNullDate(val)
+	case "status":
+		t.Status = cellString(val)
+	case "notes":
+		t.Notes = cellString(val)
+	case "created":
+		err = cellTime(&t.Created, val)
+	case "updated":
+		err = cellTime(&t.Updated, val)
+	default:
+		err = fmt.Errorf("unknown column")
+	}
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// formatRecord renders one row as aligned "column: value" lines, showing blank
+// cells as (empty) and dates in the display locale
+func formatRecord(columns, cells []string) string {
+	width := 0
+	for _, c := range columns {
+		width = max(width, len(c)+1)
+	}
+	var b strings.Builder
+	for i, c := range columns {
+		v := showCell(c, cells[i])
+		if v == "" {
+			v = "(empty)"
+		}
+		fmt.Fprintf(&b, "%-*s  %s\n", width, c+":", v)
+	}
+	return b.String()
+}
+
+// showCell turns an export cell back into its display form; the record
+// functions keep RFC3339 / ISO dates so export & import stay locale-free
+func showCell(column, v string) string {
+	switch column {
+	case "occurred", "created", "updated":
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return humanDateTime(t)
+		}
+	case "duedate":
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return humanDate(t)
+		}
+	}
+	return v
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// formatNullInt renders NULL as a blank cell, keeping a stored 0 as "0"
+func formatNullInt(n null.Int64) string {
+	if !n.Valid {
+		return ""
+	}
+	return strconv.FormatInt(n.Int64, 10)
+}
+
+// cellString maps a blank cell to NULL
+func cellString(val string) null.String {
+	if val == "" {
+		return null.String{}
+	}
+	return null.StringFrom(val)
+}
+
+func cellInt(val string) (int64, error) {
+	return strconv.ParseInt(val, 10, 64)
+}
+
+// cellNullInt maps a blank cell to NULL
+func cellNullInt(val string) (null.Int64, error) {
+	if val == "" {
+		return null.Int64{}, nil
+	}
+	n, err := cellInt(val)
+	return null.Int64From(n), err
+}
+
+// cellNullKey is cellNullInt for optional foreign keys; 0 also reads as
+// unset, which is how older exports wrote them
+func cellNullKey(val string) (null.Int64, error) {
+	if val == "0" {
+		return null.Int64{}, nil
+	}
+	return cellNullInt(val)
+}
+
+// cellNullDate reads YYYY-MM-DD; older exports wrote an unset date as 0001-01-01
+func cellNullDate(val string) (null.Time, error) {
+	if val == "" || val == "0001-01-01" {
+		return null.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", val)
+	return null.TimeFrom(t), err
+}
+
+// cellTime reads RFC 3339 into dst; a blank cell leaves dst as it was, so
+// updates keep the stored time and inserts fall back to the column default
+func cellTime(dst *time.Time, val string) error {
+	if val == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return err
+	}
+	*dst = t
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
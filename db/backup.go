@@ -0,0 +1,40 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package db
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Backup writes a consistent, compacted copy of the open database to dest.
+// VACUUM INTO reads a single snapshot, so it is safe while other connections
+// write, unlike copying the file and its -wal sidecar by hand.
+func Backup(ctx context.Context, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s already exists", dest)
+	}
+	_, err := Conn.ExecContext(ctx, "VACUUM INTO ?", dest)
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -21,12 +21,19 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	sqlitem "github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/mattn/go-sqlite3"
 
 	"github.com/aarondl/sqlboiler/v4/boil"
 )
@@ -35,36 +42,236 @@ import (
 
 var Conn *sql.DB
 
+// connParams are go-sqlite3 DSN options for every pooled connection: enforce
+// foreign keys (and so the schema's ON DELETE rules), let readers run during a
+// write (WAL), and wait up to 5s for a lock instead of failing with "database
+// is locked" when the TUI and a script run together
+const connParams = "?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000"
+
+// openAttempts bounds how often InitDB retries a database that stays locked
+// past the busy timeout, waiting openRetryWait between attempts
+const (
+	openAttempts  = 3
+	openRetryWait = time.Second
+)
+
+// Retrying, when set, is told about each failed attempt before InitDB waits
+// and tries a locked database again
+var Retrying func(attempt int, err error)
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// InitDB opens the file, applies migrations, and hooks up SQLBoiler.
-func InitDB(path string) (*sql.DB, error) {
+// InitDB opens the database, applies migrations, and hooks up SQLBoiler.
+// location is a plain sqlite path or a URL understood by ParseURL.
+func InitDB(location string) (*sql.DB, error) {
+	driver, dsn, err := ParseURL(location)
+	if err != nil {
+		return nil, err
+	}
+	if driver != DriverSQLite {
+		return nil, fmt.Errorf("%s databases are not supported yet", driver)
+	}
+
+	db, err := openRetrying(dsn + connParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := Migrate(db); err != nil {
+		return nil, err
+	}
+
+	boil.SetDB(db)
+	Conn = db
+	return db, nil
+}
+
+// Open opens the sqlite file without touching its schema.
+func Open(path string) (*sql.DB, error) {
 	db, err := sql.Open("sqlite3", path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	return db, nil
+}
+
+// openRetrying opens dsn on a single connection and pings it, retrying while
+// another process keeps the file locked. One connection means every statement
+// queues behind the busy timeout instead of racing a second writer in-process
+func openRetrying(dsn string) (*sql.DB, error) {
+	db, err := Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	for attempt := 1; ; attempt++ {
+		err = db.PingContext(Ctx())
+		if err == nil || !isLocked(err) || attempt == openAttempts {
+			break
+		}
+		if Retrying != nil {
+			Retrying(attempt, err)
+		}
+		time.Sleep(openRetryWait)
+	}
+	if err != nil {
+		db.Close()
+		if isLocked(err) {
+			return nil, fmt.Errorf("database is locked by another process (gave up after %d attempts)", openAttempts)
+		}
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// isLocked reports whether err is sqlite giving up on a lock held elsewhere
+func isLocked(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked)
+}
+
+// OpenReadOnly opens an existing sqlite file for reading only; unlike Open
+// it fails instead of creating a missing file.
+func OpenReadOnly(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// NewMigrator binds golang-migrate to an open connection.
+// The returned instance must not be closed, as that closes db too.
+func NewMigrator(db *sql.DB) (*migrate.Migrate, error) {
+	// golang-migrate's own error for a missing source is opaque
+	if MigrationsDir != "" {
+		if _, err := os.Stat(MigrationsDir); errors.Is(err, fs.ErrNotExist) {
+			abs, _ := filepath.Abs(MigrationsDir)
+			return nil, fmt.Errorf("migrations directory not found at %s", abs)
+		}
+	}
+
+	src, err := iofs.New(migrationFS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("initializing migrations: %w", err)
+	}
 
 	driver, err := sqlitem.WithInstance(db, &sqlitem.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("initializing migrations: %w", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"sqlite3",
-		driver,
-	)
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite3", driver)
 	if err != nil {
 		return nil, fmt.Errorf("initializing migrations: %w", err)
 	}
+	return m, nil
+}
+
+// Migrate applies pending up migrations and reports the schema version
+// before and after (0 means no migration had been applied).
+func Migrate(db *sql.DB) (from, to uint, err error) {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from, err = Version(m); err != nil {
+		return 0, 0, err
+	}
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return nil, fmt.Errorf("applying migrations: %w", err)
+		return from, from, fmt.Errorf("applying migrations: %w", err)
 	}
 
-	boil.SetDB(db)
-	Conn = db
-	return db, nil
+	if to, err = Version(m); err != nil {
+		return from, from, err
+	}
+	return from, to, nil
+}
+
+// Rollback reverts the last steps applied migrations and reports the schema
+// version before and after.
+func Rollback(db *sql.DB, steps int) (from, to uint, err error) {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from, err = Version(m); err != nil {
+		return 0, 0, err
+	}
+	// golang-migrate would revert what it can before failing on a short limit
+	applied, err := countScripts("down", func(v uint) bool { return v <= from })
+	if err != nil {
+		return from, from, err
+	}
+	if steps > applied {
+		return from, from, fmt.Errorf("cannot roll back %d migration(s), only %d applied", steps, applied)
+	}
+
+	if err := m.Steps(-steps); err != nil {
+		return from, from, fmt.Errorf("rolling back migrations: %w", err)
+	}
+
+	if to, err = Version(m); err != nil {
+		return from, from, err
+	}
+	return from, to, nil
+}
+
+// countScripts counts the migration scripts of kind ("up" or "down") whose
+// version satisfies match
+func countScripts(kind string, match func(version uint) bool) (int, error) {
+	files, err := fs.Glob(migrationFS(), "*."+kind+".sql")
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, f := range files {
+		prefix, _, _ := strings.Cut(f, "_")
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err == nil && match(uint(v)) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Status reports the applied schema version, whether a failed migration left
+// it dirty, and how many up migrations are still pending. Nothing is applied.
+func Status(db *sql.DB) (version uint, dirty bool, pending int, err error) {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return 0, false, 0, err
+	}
+
+	version, dirty, err = m.Version()
+	if err == migrate.ErrNilVersion {
+		version, dirty, err = 0, false, nil
+	}
+	if err != nil {
+		return 0, false, 0, fmt.Errorf("reading schema version: %w", err)
+	}
+
+	pending, err = countScripts("up", func(v uint) bool { return v > version })
+	return version, dirty, pending, err
+}
+
+// Version returns the applied schema version, 0 for a fresh database.
+func Version(m *migrate.Migrate) (uint, error) {
+	v, _, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return v, nil
 }
 
 // Ctx returns a base context for all DB operations.
@@ -18,12 +18,26 @@ package db
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// MigrationsDir is the relative path (from your binary's working directory)
-// to where your golang-migrate files live.
-//
-// Expected layout at project root:
-//
-//	migrations/
-const MigrationsDir = "migrations"
+import (
+	"io/fs"
+	"os"
+
+	"github.com/DanielRivasMD/Zenith/migrations"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MigrationsDir, when set, loads the migration scripts from that directory
+// instead of the copies embedded in the binary, e.g. to try a new script
+// before rebuilding
+var MigrationsDir string
+
+// migrationFS is where the migration scripts are read from
+func migrationFS() fs.FS {
+	if MigrationsDir != "" {
+		return os.DirFS(MigrationsDir)
+	}
+	return migrations.FS
+}
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package db
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+
+	"github.com/aarondl/sqlboiler/v4/queries/qm"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PageSize is the number of rows fetched per page by bulk commands
+const PageSize = 500
+
+// Query runs a base query with extra mods appended, e.g. a closure over
+// models.Orgs(mods...).All
+type Query[T any] func(ctx context.Context, mods ...qm.QueryMod) ([]T, error)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Paginate runs query in pages of pageSize rows and hands each page to fn,
+// so only one page is held at a time. The base query must have a stable
+// ORDER BY and no LIMIT of its own; an error from fn stops the iteration
+func Paginate[T any](ctx context.Context, query Query[T], pageSize int, fn func([]T) error) error {
+	return PaginateFrom(ctx, query, 0, pageSize, fn)
+}
+
+// PaginateFrom is Paginate skipping the first start rows
+func PaginateFrom[T any](ctx context.Context, query Query[T], start, pageSize int, fn func([]T) error) error {
+	if pageSize <= 0 {
+		pageSize = PageSize
+	}
+	for offset := start; ; offset += pageSize {
+		page, err := query(ctx, qm.Limit(pageSize), qm.Offset(offset))
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
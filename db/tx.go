@@ -0,0 +1,49 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package db
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aarondl/sqlboiler/v4/boil"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// WithTx runs fn in a transaction, committing when it returns nil and rolling
+// back otherwise, so a failed multi-step write leaves nothing behind
+func WithTx(ctx context.Context, fn func(exec boil.ContextExecutor) error) error {
+	tx, err := Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return finishTx(tx, fn(tx))
+}
+
+// finishTx commits tx, or rolls it back when the work inside failed with err
+func finishTx(tx *sql.Tx, err error) error {
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,66 @@
+/*
+Copyright © 2025 Daniel Rivas <danielrivasmd@gmail.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+package db
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// database/sql driver names selected by ParseURL
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ParseURL picks the driver & DSN for a database location:
+//
+//	zenith.db                  → sqlite3, zenith.db
+//	sqlite:///var/zenith.db    → sqlite3, /var/zenith.db
+//	sqlite://zenith.db         → sqlite3, zenith.db
+//	postgres://user@host/name  → postgres, the URL unchanged
+func ParseURL(raw string) (driver, dsn string, err error) {
+	if !strings.Contains(raw, "://") {
+		return DriverSQLite, raw, nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid database URL %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "sqlite", "sqlite3":
+		path := u.Host + u.Path
+		if path == "" {
+			return "", "", fmt.Errorf("invalid database URL %q: missing path", raw)
+		}
+		return DriverSQLite, path, nil
+	case "postgres", "postgresql":
+		return DriverPostgres, raw, nil
+	default:
+		return "", "", fmt.Errorf("unsupported database URL scheme %q (valid: sqlite, postgres)", u.Scheme)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
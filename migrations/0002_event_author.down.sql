@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE events DROP COLUMN author;
+
+----------------------------------------------------------------------------------------------------
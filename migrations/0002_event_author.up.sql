@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE events ADD COLUMN author text;
+
+----------------------------------------------------------------------------------------------------
@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+DROP TABLE event_contacts;
+
+----------------------------------------------------------------------------------------------------
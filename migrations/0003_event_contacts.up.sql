@@ -0,0 +1,9 @@
+----------------------------------------------------------------------------------------------------
+CREATE TABLE event_contacts (
+	event integer NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+	contact integer NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+	created DATETIME NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+	PRIMARY KEY (event, contact)
+);
+
+----------------------------------------------------------------------------------------------------
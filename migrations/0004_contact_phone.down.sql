@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE contacts DROP COLUMN phone;
+
+----------------------------------------------------------------------------------------------------
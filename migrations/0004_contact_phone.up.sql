@@ -0,0 +1,4 @@
+----------------------------------------------------------------------------------------------------
+ALTER TABLE contacts ADD COLUMN phone text;
+
+----------------------------------------------------------------------------------------------------
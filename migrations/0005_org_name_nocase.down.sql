@@ -0,0 +1,7 @@
+----------------------------------------------------------------------------------------------------
+DROP INDEX orgs_name_nocase;
+
+----------------------------------------------------------------------------------------------------
+ALTER TABLE orgs DROP COLUMN allow_duplicate_name;
+
+----------------------------------------------------------------------------------------------------
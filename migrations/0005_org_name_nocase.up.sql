@@ -0,0 +1,14 @@
+----------------------------------------------------------------------------------------------------
+-- orgs flagged allow_duplicate_name opt out of the case-insensitive check
+ALTER TABLE orgs ADD COLUMN allow_duplicate_name integer NOT NULL DEFAULT 0;
+
+----------------------------------------------------------------------------------------------------
+-- names that already differ only by case keep the oldest org under the check
+-- and let the rest through, so the index below can be built
+UPDATE orgs SET allow_duplicate_name = 1
+WHERE id NOT IN (SELECT MIN(id) FROM orgs GROUP BY name COLLATE NOCASE);
+
+----------------------------------------------------------------------------------------------------
+CREATE UNIQUE INDEX orgs_name_nocase ON orgs (name COLLATE NOCASE) WHERE allow_duplicate_name = 0;
+
+----------------------------------------------------------------------------------------------------